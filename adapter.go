@@ -18,10 +18,14 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/pjscruggs/slogcp"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // Logger adapts go-grpc-middleware logging calls to a [slog.Logger].
@@ -29,11 +33,71 @@ import (
 type Logger struct {
 	log      *slog.Logger
 	mapLevel func(grpc_logging.Level) slog.Level
+	registry *callRegistry
+	// calls tracks every server call in progress for Stop; registry, when
+	// set, is the same registry.
+	calls *callRegistry
+
+	deadlineBudget   *DeadlineBudget
+	terminationLevel func(TerminationCause, slog.Level) slog.Level
+	redactor         *Redactor
+	protos           *protoRenderer
+	payloadFields    *PayloadFieldSelector
+	sizeLimits       *SizeLimits
+	chunkBytes       int
+	metadataFilter   *metadataFilter
+	logPeerIdentity  bool
+	certWarnings     *certWarnings
+	callerExtractors []CallerExtractor
+	callerKey        []byte
+	ipAnonymizer     *ipAnonymizer
+	transformers     []Transformer
+	durationFormat   DurationFormat
+	reservedPolicy   ReservedKeyPolicy
+	reservedPrefix   string
+	reservedDrops    atomic.Uint64
+	labels           *labelPromoter
+	operations       bool
+	now              func() time.Time
+	sourceMode       SourceMode
+	handlerPCs       sync.Map // handlerKey -> uintptr
+	insertIDs        bool
+	messages         *MessageTemplates
+	summaries        bool
 }
 
 type loggerConfig struct {
-	logger      *slog.Logger
-	levelMapper func(grpc_logging.Level) slog.Level
+	logger           *slog.Logger
+	levelMapper      func(grpc_logging.Level) slog.Level
+	inFlightRegistry bool
+	deadlineBudget   *DeadlineBudget
+
+	terminationLevelMapper func(TerminationCause, slog.Level) slog.Level
+	redactor               *Redactor
+	sensitiveMode          SensitiveFieldMode
+	sensitiveOptions       []protoreflect.ExtensionType
+	emitUnpopulated        bool
+	protoFieldNames        bool
+	payloadFields          *PayloadFieldSelector
+	sizeLimits             *SizeLimits
+	chunkBytes             int
+	metadata               metadataConfig
+	peerIdentity           bool
+	certExpiryWindow       time.Duration
+	callerExtractors       []CallerExtractor
+	callerKey              []byte
+	ipAnonymizer           *ipAnonymizer
+	transformers           []Transformer
+	durationFormat         DurationFormat
+	reservedPolicy         ReservedKeyPolicy
+	reservedPrefix         string
+	labelFields            []string
+	operations             bool
+	clock                  func() time.Time
+	sourceMode             SourceMode
+	insertIDs              bool
+	messages               *MessageTemplates
+	summaries              bool
 }
 
 // LoggerOption configures a [Logger] created by [NewLogger].
@@ -51,7 +115,10 @@ type LoggerOption func(*loggerConfig)
 //	)
 func NewLogger(handler *slogcp.Handler, opts ...LoggerOption) *Logger {
 	cfg := loggerConfig{
-		levelMapper: defaultLevelMapper,
+		levelMapper:            defaultLevelMapper,
+		terminationLevelMapper: defaultTerminationLevel,
+		reservedPrefix:         DefaultReservedKeyPrefix,
+		clock:                  time.Now,
 	}
 	for _, opt := range opts {
 		if opt != nil {
@@ -71,10 +138,41 @@ func NewLogger(handler *slogcp.Handler, opts ...LoggerOption) *Logger {
 		cfg.levelMapper = defaultLevelMapper
 	}
 
-	return &Logger{
-		log:      cfg.logger,
-		mapLevel: cfg.levelMapper,
+	l := &Logger{
+		log:              cfg.logger,
+		mapLevel:         cfg.levelMapper,
+		deadlineBudget:   cfg.deadlineBudget,
+		terminationLevel: cfg.terminationLevelMapper,
+		redactor:         cfg.redactor,
+		protos:           newProtoRenderer(&cfg),
+		payloadFields:    cfg.payloadFields,
+		sizeLimits:       cfg.sizeLimits,
+		chunkBytes:       cfg.chunkBytes,
+		metadataFilter:   newMetadataFilter(cfg.metadata, cfg.ipAnonymizer),
+		logPeerIdentity:  cfg.peerIdentity,
+		callerExtractors: cfg.callerExtractors,
+		callerKey:        cfg.callerKey,
+		ipAnonymizer:     cfg.ipAnonymizer,
+		transformers:     cfg.transformers,
+		durationFormat:   cfg.durationFormat,
+		reservedPolicy:   cfg.reservedPolicy,
+		reservedPrefix:   cfg.reservedPrefix,
+		labels:           newLabelPromoter(cfg.labelFields),
+		operations:       cfg.operations,
+		now:              cfg.clock,
+		sourceMode:       cfg.sourceMode,
+		insertIDs:        cfg.insertIDs,
+		messages:         cfg.messages,
+		summaries:        cfg.summaries,
+	}
+	l.calls = newCallRegistry()
+	if cfg.inFlightRegistry {
+		l.registry = l.calls
 	}
+	if cfg.certExpiryWindow > 0 {
+		l.certWarnings = newCertWarnings(cfg.certExpiryWindow)
+	}
+	return l
 }
 
 // WithLogger makes [NewLogger] use logger instead of constructing one from a handler.
@@ -104,13 +202,49 @@ func WithLevelMapper(mapper func(grpc_logging.Level) slog.Level) LoggerOption {
 }
 
 // Log forwards one go-grpc-middleware log event to the underlying [slog.Logger].
-// It preserves ctx so slogcp can attach trace correlation fields.
+// It preserves ctx so slogcp can attach trace correlation fields, and stamps
+// started call entries with the call's start time rather than the time Log ran.
+// As with [slog.Logger], entries whose mapped level the handler is disabled
+// for are dropped before any other work, so later level changes, such as
+// deadline budget escalation, only apply to entries the handler accepts.
 func (l *Logger) Log(ctx context.Context, level grpc_logging.Level, msg string, fields ...any) {
 	if l == nil || l.log == nil {
 		return
 	}
-	attrs := buildAttrs(fields)
-	l.log.LogAttrs(ctx, l.mapLevel(level), msg, attrs...)
+	slogLevel := l.mapLevel(level)
+	if !l.enabled(ctx, slogLevel) {
+		return
+	}
+	attrs := l.protectReserved(buildAttrs(fields, l.durationFormat))
+	attrs = l.ipAnonymizer.anonymizePeer(attrs)
+	attrs = l.redact(attrs)
+	if l.protos != nil {
+		attrs = l.protos.renderAttrs(attrs)
+	}
+	call, _ := callFromContext(ctx)
+	attrs, ok := l.summarize(call, msg, slogLevel, attrs)
+	if !ok {
+		return
+	}
+	l.emit(ctx, call, slogLevel, msg, attrs)
+}
+
+// emit annotates, transforms and writes one entry for call, which may be nil.
+func (l *Logger) emit(ctx context.Context, call *callState, slogLevel slog.Level, msg string, attrs []slog.Attr) {
+	at := l.entryTime(call, msg, attrs)
+	if call != nil {
+		slogLevel, attrs = l.annotateCall(call, at, msg, slogLevel, attrs)
+	}
+	attrs = l.appendInsertID(call, msg, l.labels.promote(attrs))
+	e, ok := l.transform(ctx, at, l.sourcePC(call), slogLevel, l.messages.render(call, msg, attrs), attrs)
+	if !ok {
+		return
+	}
+	if l.chunkBytes > 0 && l.logChunked(e) {
+		return
+	}
+	e.Attrs = l.sizeLimits.limitAttrs(e.Message, e.Attrs)
+	l.write(e.Context, e.Time, e.Level, e.Message, e.PC, e.Attrs)
 }
 
 // UnaryServerInterceptor returns a unary server interceptor that logs through slogcp.
@@ -122,7 +256,7 @@ func (l *Logger) Log(ctx context.Context, level grpc_logging.Level, msg string,
 //		grpc.ChainUnaryInterceptor(slogcpadapter.UnaryServerInterceptor(handler)),
 //	)
 func UnaryServerInterceptor(handler *slogcp.Handler, opts ...grpc_logging.Option) grpc.UnaryServerInterceptor {
-	return grpc_logging.UnaryServerInterceptor(NewLogger(handler), opts...)
+	return NewLogger(handler).UnaryServerInterceptor(opts...)
 }
 
 // StreamServerInterceptor returns a stream server interceptor that logs through slogcp.
@@ -134,7 +268,7 @@ func UnaryServerInterceptor(handler *slogcp.Handler, opts ...grpc_logging.Option
 //		grpc.ChainStreamInterceptor(slogcpadapter.StreamServerInterceptor(handler)),
 //	)
 func StreamServerInterceptor(handler *slogcp.Handler, opts ...grpc_logging.Option) grpc.StreamServerInterceptor {
-	return grpc_logging.StreamServerInterceptor(NewLogger(handler), opts...)
+	return NewLogger(handler).StreamServerInterceptor(opts...)
 }
 
 // UnaryClientInterceptor returns a unary client interceptor that logs through slogcp.
@@ -148,7 +282,7 @@ func StreamServerInterceptor(handler *slogcp.Handler, opts ...grpc_logging.Optio
 //		grpc.WithChainUnaryInterceptor(slogcpadapter.UnaryClientInterceptor(handler)),
 //	)
 func UnaryClientInterceptor(handler *slogcp.Handler, opts ...grpc_logging.Option) grpc.UnaryClientInterceptor {
-	return grpc_logging.UnaryClientInterceptor(NewLogger(handler), opts...)
+	return NewLogger(handler).UnaryClientInterceptor(opts...)
 }
 
 // StreamClientInterceptor returns a stream client interceptor that logs through slogcp.
@@ -162,7 +296,7 @@ func UnaryClientInterceptor(handler *slogcp.Handler, opts ...grpc_logging.Option
 //		grpc.WithChainStreamInterceptor(slogcpadapter.StreamClientInterceptor(handler)),
 //	)
 func StreamClientInterceptor(handler *slogcp.Handler, opts ...grpc_logging.Option) grpc.StreamClientInterceptor {
-	return grpc_logging.StreamClientInterceptor(NewLogger(handler), opts...)
+	return NewLogger(handler).StreamClientInterceptor(opts...)
 }
 
 // defaultLevelMapper converts go-grpc-middleware levels into slog levels.
@@ -173,20 +307,36 @@ func defaultLevelMapper(level grpc_logging.Level) slog.Level {
 	return slog.Level(level)
 }
 
-// buildAttrs converts go-grpc-middleware key/value fields into slog attributes.
-func buildAttrs(fields []any) []slog.Attr {
+// badKey is the key slog gives values that have no key.
+const badKey = "!BADKEY"
+
+// buildAttrs converts go-grpc-middleware key/value fields into slog attributes,
+// rendering durations in durations; see typedValue. Like [slog.Logger.Log], it
+// passes slog.Attr and []slog.Attr entries through intact and logs a slog.Value
+// or a trailing value without a pair under "!BADKEY".
+func buildAttrs(fields []any, durations DurationFormat) []slog.Attr {
 	if len(fields) == 0 {
 		return nil
 	}
 
 	attrs := make([]slog.Attr, 0, (len(fields)+1)/2)
-	for i := 0; i < len(fields); i += 2 {
-		key := fmt.Sprint(fields[i])
-		var val any
-		if i+1 < len(fields) {
-			val = fields[i+1]
+	for i := 0; i < len(fields); i++ {
+		switch x := fields[i].(type) {
+		case slog.Attr:
+			attrs = append(attrs, x)
+		case []slog.Attr:
+			attrs = append(attrs, x...)
+		case slog.Value:
+			attrs = append(attrs, slog.Attr{Key: badKey, Value: x})
+		default:
+			if i+1 == len(fields) {
+				attrs = append(attrs, slog.Attr{Key: badKey, Value: typedValue(x, durations)})
+				break
+			}
+			key := fmt.Sprint(x)
+			attrs = append(attrs, slog.Attr{Key: key, Value: fieldValue(key, fields[i+1], durations)})
+			i++
 		}
-		attrs = append(attrs, slog.Any(key, val))
 	}
 	return attrs
 }
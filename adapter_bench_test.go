@@ -16,8 +16,11 @@ package slogcpadapter
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log/slog"
 	"testing"
+	"time"
 
 	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 )
@@ -60,3 +63,57 @@ func BenchmarkLoggerLevelMapping(b *testing.B) {
 		adapter.Log(ctx, grpc_logging.LevelWarn, "bench")
 	}
 }
+
+// BenchmarkLoggerTransformers measures the overhead of a transformer chain.
+func BenchmarkLoggerTransformers(b *testing.B) {
+	chains := []struct {
+		name         string
+		transformers []Transformer
+	}{
+		{name: "none"},
+		{name: "rename", transformers: []Transformer{RenameKey("user", "principal")}},
+		{name: "builtins", transformers: []Transformer{
+			RenameKey("user", "principal"),
+			DropKeys("ok"),
+			AddStatic(slog.String("team", "payments")),
+			MapValue("id", func(v slog.Value) slog.Value { return slog.StringValue(v.String()) }),
+		}},
+	}
+	for _, chain := range chains {
+		b.Run(chain.name, func(b *testing.B) {
+			adapter := NewLogger(nil, WithLogger(slog.New(discardHandler{})), WithTransformers(chain.transformers...))
+			ctx := context.Background()
+			for i := 0; b.Loop(); i++ {
+				adapter.Log(ctx, grpc_logging.LevelInfo, "bench",
+					"id", i,
+					"user", "abc",
+					"ok", true,
+				)
+			}
+		})
+	}
+}
+
+// BenchmarkBuildAttrs compares typed field conversion with plain slog.Any wrapping.
+func BenchmarkBuildAttrs(b *testing.B) {
+	fields := []any{
+		"grpc.service", "pkg.Svc",
+		"grpc.start_time", "2026-03-04T05:06:07Z",
+		"grpc.time_ms", "1.5",
+		"elapsed", 1500 * time.Millisecond,
+		"err", fmt.Errorf("wrapped: %w", io.EOF),
+	}
+	b.Run("any", func(b *testing.B) {
+		for b.Loop() {
+			attrs := make([]slog.Attr, 0, len(fields)/2)
+			for i := 0; i < len(fields); i += 2 {
+				attrs = append(attrs, slog.Any(fmt.Sprint(fields[i]), fields[i+1]))
+			}
+		}
+	})
+	b.Run("typed", func(b *testing.B) {
+		for b.Loop() {
+			buildAttrs(fields, DurationSeconds)
+		}
+	})
+}
@@ -81,11 +81,11 @@ func TestLoggerLogConvertsFields(t *testing.T) {
 	if got := attrs["99"]; got != true {
 		t.Fatalf("expected coerced key '99', got %v", got)
 	}
-	if _, ok := attrs["lonely"]; !ok {
-		t.Fatalf("expected lonely key to be present")
+	if _, ok := attrs["lonely"]; ok {
+		t.Fatalf("expected dangling value not to become a key")
 	}
-	if got := attrs["lonely"]; got != nil {
-		t.Fatalf("expected lonely value to be nil, got %v", got)
+	if got := attrs["!BADKEY"]; got != "lonely" {
+		t.Fatalf("expected dangling value under !BADKEY, got %v", got)
 	}
 }
 
@@ -165,11 +165,42 @@ func TestNewLoggerUsesHandlerAndDefaultFallback(t *testing.T) {
 
 // TestBuildAttrsHandlesEmptyFields verifies that empty field slices produce no attributes.
 func TestBuildAttrsHandlesEmptyFields(t *testing.T) {
-	if attrs := buildAttrs(nil); attrs != nil {
+	if attrs := buildAttrs(nil, DurationSeconds); attrs != nil {
 		t.Fatalf("expected nil for empty fields, got %v", attrs)
 	}
 }
 
+// TestBuildAttrsPassesSlogAttrs verifies slog.Attr, []slog.Attr and
+// slog.Value entries do not shift the key/value pairs that follow them.
+func TestBuildAttrsPassesSlogAttrs(t *testing.T) {
+	attrs := buildAttrs([]any{
+		slog.Group("tenant", slog.String("id", "t-1")),
+		"grpc.service", "pkg.Svc",
+		[]slog.Attr{slog.Int("retries", 2), slog.Bool("cached", true)},
+		slog.IntValue(7),
+		"grpc.method", "Get",
+		"dangling",
+	}, DurationSeconds)
+
+	want := []slog.Attr{
+		slog.Group("tenant", slog.String("id", "t-1")),
+		slog.String("grpc.service", "pkg.Svc"),
+		slog.Int("retries", 2),
+		slog.Bool("cached", true),
+		slog.Int("!BADKEY", 7),
+		slog.String("grpc.method", "Get"),
+		slog.String("!BADKEY", "dangling"),
+	}
+	if len(attrs) != len(want) {
+		t.Fatalf("expected %d attributes, got %d: %v", len(want), len(attrs), attrs)
+	}
+	for i := range want {
+		if !attrs[i].Equal(want[i]) {
+			t.Errorf("attribute %d: expected %v, got %v", i, want[i], attrs[i])
+		}
+	}
+}
+
 // TestDefaultLevelMapper verifies the default mapping for known and unknown levels.
 func TestDefaultLevelMapper(t *testing.T) {
 	tests := []struct {
@@ -278,3 +309,23 @@ func collectAttrs(r slog.Record) map[string]any {
 	})
 	return out
 }
+
+// attrValue returns the value of the top-level attribute key in r.
+func attrValue(t *testing.T, r slog.Record, key string) slog.Value {
+	t.Helper()
+	var (
+		v     slog.Value
+		found bool
+	)
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == key {
+			v, found = a.Value, true
+			return false
+		}
+		return true
+	})
+	if !found {
+		t.Fatalf("attribute %q not found", key)
+	}
+	return v
+}
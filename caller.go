@@ -0,0 +1,217 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// CallerKey is the group describing the authenticated caller.
+const CallerKey = "caller"
+
+// Metadata keys set by Google front ends.
+const (
+	IAPAssertionMetadata      = "x-goog-iap-jwt-assertion"
+	EndpointsUserInfoMetadata = "x-endpoint-api-userinfo"
+)
+
+// pseudonymizedClaims are the claims replaced by HMAC pseudonyms when
+// [WithCallerPseudonymization] is set.
+var pseudonymizedClaims = map[string]bool{"sub": true, "id": true, "email": true}
+
+// Caller is the identity a [CallerExtractor] found in request metadata.
+type Caller struct {
+	// Source names where the identity came from, such as "jwt" or "iap".
+	Source string
+	// Claims holds the selected identity claims.
+	Claims map[string]any
+}
+
+// CallerExtractor finds the caller's identity in incoming metadata, reporting
+// false when the metadata carries none.
+type CallerExtractor func(md metadata.MD) (Caller, bool)
+
+// WithCallerExtractors makes the Logger's server interceptors log the caller
+// found by the first matching extractor under [CallerKey] on every entry of
+// the call. It may be given several times; nil extractors are ignored.
+//
+// Example:
+//
+//	adapter := slogcpadapter.NewLogger(handler,
+//		slogcpadapter.WithCallerExtractors(
+//			slogcpadapter.IAPExtractor(),
+//			slogcpadapter.EndpointsUserInfoExtractor(),
+//			slogcpadapter.BearerJWTExtractor("sub", "email", "tenant"),
+//		),
+//	)
+func WithCallerExtractors(extractors ...CallerExtractor) LoggerOption {
+	return func(cfg *loggerConfig) {
+		for _, e := range extractors {
+			if e != nil {
+				cfg.callerExtractors = append(cfg.callerExtractors, e)
+			}
+		}
+	}
+}
+
+// WithCallerPseudonymization replaces the sub, id and email claims of logged
+// callers with keyed HMAC digests, so requests from one caller can still be
+// correlated without logging who they are. An empty key is ignored.
+func WithCallerPseudonymization(hmacKey []byte) LoggerOption {
+	return func(cfg *loggerConfig) {
+		if len(hmacKey) > 0 {
+			cfg.callerKey = hmacKey
+		}
+	}
+}
+
+// BearerJWTExtractor reads the named claims from a JWT in the authorization
+// header, by default sub, email, iss and aud. The token's signature is not
+// verified: the claims are only as trustworthy as whatever authenticated the
+// request before it reached the server.
+func BearerJWTExtractor(claims ...string) CallerExtractor {
+	claims = defaultClaims(claims, "sub", "email", "iss", "aud")
+	return func(md metadata.MD) (Caller, bool) {
+		for _, v := range md.Get("authorization") {
+			if len(v) > 7 && strings.EqualFold(v[:7], "bearer ") {
+				return jwtCaller("jwt", strings.TrimSpace(v[7:]), claims)
+			}
+		}
+		return Caller{}, false
+	}
+}
+
+// IAPExtractor reads the named claims, by default sub, email and hd, from the
+// JWT that Identity-Aware Proxy adds in x-goog-iap-jwt-assertion. Like
+// [BearerJWTExtractor], it does not verify the signature.
+func IAPExtractor(claims ...string) CallerExtractor {
+	claims = defaultClaims(claims, "sub", "email", "hd")
+	return func(md metadata.MD) (Caller, bool) {
+		if v := md.Get(IAPAssertionMetadata); len(v) > 0 {
+			return jwtCaller("iap", v[0], claims)
+		}
+		return Caller{}, false
+	}
+}
+
+// EndpointsUserInfoExtractor reads the named claims, by default sub, id,
+// email, iss and issuer, from the base64-encoded JSON that Cloud Endpoints and
+// ESPv2 add in x-endpoint-api-userinfo after authenticating the caller.
+func EndpointsUserInfoExtractor(claims ...string) CallerExtractor {
+	claims = defaultClaims(claims, "sub", "id", "email", "iss", "issuer")
+	return func(md metadata.MD) (Caller, bool) {
+		v := md.Get(EndpointsUserInfoMetadata)
+		if len(v) == 0 {
+			return Caller{}, false
+		}
+		payload, err := decodeSegment(v[0])
+		if err != nil {
+			return Caller{}, false
+		}
+		return claimsCaller("endpoints", payload, claims)
+	}
+}
+
+func defaultClaims(claims []string, defaults ...string) []string {
+	if len(claims) == 0 {
+		return defaults
+	}
+	return claims
+}
+
+// jwtCaller decodes the payload of token without verifying it.
+func jwtCaller(source, token string, claims []string) (Caller, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Caller{}, false
+	}
+	payload, err := decodeSegment(parts[1])
+	if err != nil {
+		return Caller{}, false
+	}
+	return claimsCaller(source, payload, claims)
+}
+
+// claimsCaller selects claims from a JSON object.
+func claimsCaller(source string, payload []byte, claims []string) (Caller, bool) {
+	var all map[string]any
+	if err := json.Unmarshal(payload, &all); err != nil {
+		return Caller{}, false
+	}
+	selected := make(map[string]any, len(claims))
+	for _, c := range claims {
+		if v, ok := all[c]; ok {
+			selected[c] = v
+		}
+	}
+	return Caller{Source: source, Claims: selected}, true
+}
+
+// decodeSegment decodes base64url or standard base64, padded or not.
+func decodeSegment(s string) ([]byte, error) {
+	s = strings.TrimRight(strings.TrimSpace(s), "=")
+	if b, err := base64.RawURLEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	b, err := base64.RawStdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("slogcpadapter: decoding base64 segment: %w", err)
+	}
+	return b, nil
+}
+
+// callerAttrs runs the configured extractors on the incoming metadata in ctx
+// and returns the first caller found as attributes, or nil.
+func (l *Logger) callerAttrs(ctx context.Context) []slog.Attr {
+	if len(l.callerExtractors) == 0 {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, extract := range l.callerExtractors {
+		if caller, ok := extract(md); ok {
+			return l.renderCaller(caller)
+		}
+	}
+	return nil
+}
+
+// renderCaller converts caller to attributes, pseudonymizing identifying claims.
+func (l *Logger) renderCaller(caller Caller) []slog.Attr {
+	keys := make([]string, 0, len(caller.Claims))
+	for k := range caller.Claims {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	attrs := make([]slog.Attr, 0, len(keys)+1)
+	if caller.Source != "" {
+		attrs = append(attrs, slog.String("source", caller.Source))
+	}
+	for _, k := range keys {
+		v := caller.Claims[k]
+		if len(l.callerKey) > 0 && pseudonymizedClaims[k] {
+			v = hmacDigest(l.callerKey, fmt.Sprint(v))
+		}
+		attrs = append(attrs, slog.Any(k, v))
+	}
+	return attrs
+}
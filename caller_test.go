@@ -0,0 +1,143 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"encoding/base64"
+	"log/slog"
+	"net"
+	"reflect"
+	"testing"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// testJWT returns an unsigned JWT carrying payload.
+func testJWT(payload string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"RS256"}`)) + "." + enc.EncodeToString([]byte(payload)) + ".c2ln"
+}
+
+// runWithMetadata runs one unary server call carrying md and returns the caller group logged.
+func runWithMetadata(t *testing.T, logger *Logger, rec *recordingHandler, md metadata.MD) any {
+	t.Helper()
+	interceptor := logger.UnaryServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.FinishCall))
+	ctx := peer.NewContext(metadata.NewIncomingContext(context.Background(), md), &peer.Peer{Addr: &net.TCPAddr{}})
+	_, _ = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Get"}, func(context.Context, any) (any, error) { return nil, nil })
+	var caller any
+	rec.records[len(rec.records)-1].Attrs(func(a slog.Attr) bool {
+		if a.Key == CallerKey {
+			caller = valueToAny(a.Value)
+		}
+		return true
+	})
+	return caller
+}
+
+// TestCallerExtractors verifies each built-in extractor and the extractor order.
+func TestCallerExtractors(t *testing.T) {
+	jwt := testJWT(`{"sub":"123","email":"a@example.com","iss":"https://issuer","aud":"svc","scope":"admin"}`)
+	userInfo := base64.URLEncoding.EncodeToString([]byte(`{"id":"456","email":"b@example.com","issuer":"https://endpoints"}`))
+	tests := []struct {
+		name       string
+		extractors []CallerExtractor
+		md         metadata.MD
+		want       any
+	}{
+		{
+			name:       "bearer default claims",
+			extractors: []CallerExtractor{BearerJWTExtractor()},
+			md:         metadata.Pairs("authorization", "Bearer "+jwt),
+			want:       map[string]any{"source": "jwt", "sub": "123", "email": "a@example.com", "iss": "https://issuer", "aud": "svc"},
+		},
+		{
+			name:       "bearer selected claims",
+			extractors: []CallerExtractor{BearerJWTExtractor("sub", "scope")},
+			md:         metadata.Pairs("authorization", "bearer "+jwt),
+			want:       map[string]any{"source": "jwt", "sub": "123", "scope": "admin"},
+		},
+		{
+			name:       "iap before bearer",
+			extractors: []CallerExtractor{nil, IAPExtractor(), BearerJWTExtractor()},
+			md:         metadata.Pairs(IAPAssertionMetadata, testJWT(`{"sub":"accounts.google.com:1","email":"c@example.com","hd":"example.com"}`), "authorization", "Bearer "+jwt),
+			want:       map[string]any{"source": "iap", "sub": "accounts.google.com:1", "email": "c@example.com", "hd": "example.com"},
+		},
+		{
+			name:       "endpoints user info",
+			extractors: []CallerExtractor{EndpointsUserInfoExtractor()},
+			md:         metadata.Pairs(EndpointsUserInfoMetadata, userInfo),
+			want:       map[string]any{"source": "endpoints", "id": "456", "email": "b@example.com", "issuer": "https://endpoints"},
+		},
+		{
+			name:       "malformed token",
+			extractors: []CallerExtractor{BearerJWTExtractor()},
+			md:         metadata.Pairs("authorization", "Bearer not-a-jwt"),
+			want:       nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recordingHandler{}
+			logger := NewLogger(nil, WithLogger(slog.New(rec)), WithCallerExtractors(tt.extractors...))
+			if got := runWithMetadata(t, logger, rec, tt.md); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected caller %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestCallerPseudonymization verifies subject and email are replaced by stable digests.
+func TestCallerPseudonymization(t *testing.T) {
+	rec := &recordingHandler{}
+	key := []byte("caller-key")
+	logger := NewLogger(nil, WithLogger(slog.New(rec)),
+		WithCallerExtractors(BearerJWTExtractor()),
+		WithCallerPseudonymization(key),
+	)
+	md := metadata.Pairs("authorization", "Bearer "+testJWT(`{"sub":"123","email":"a@example.com","iss":"https://issuer"}`))
+
+	want := map[string]any{
+		"source": "jwt",
+		"sub":    hmacDigest(key, "123"),
+		"email":  hmacDigest(key, "a@example.com"),
+		"iss":    "https://issuer",
+	}
+	if got := runWithMetadata(t, logger, rec, md); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected caller %v, got %v", want, got)
+	}
+	if again := runWithMetadata(t, logger, rec, md); !reflect.DeepEqual(again, want) {
+		t.Fatalf("expected pseudonyms to be stable, got %v", again)
+	}
+}
+
+// TestCallerRedacted verifies redaction rules reach the caller group.
+func TestCallerRedacted(t *testing.T) {
+	redactor, err := NewRedactor(nil, RedactionRule{Name: "email", ValuePattern: EmailPattern})
+	if err != nil {
+		t.Fatalf("NewRedactor: %v", err)
+	}
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithRedactor(redactor), WithCallerExtractors(BearerJWTExtractor("sub", "email")))
+	md := metadata.Pairs("authorization", "Bearer "+testJWT(`{"sub":"123","email":"secret@example.com"}`))
+
+	want := map[string]any{"source": "jwt", "sub": "123", "email": RedactedValue}
+	if got := runWithMetadata(t, logger, rec, md); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected caller %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/pjscruggs/slogcp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// nextCallID hands out process-unique identifiers for tracked calls.
+var nextCallID atomic.Uint64
+
+// callState holds adapter-side bookkeeping for one RPC.
+// The Logger's interceptors store it in the call context so that Log can
+// correlate middleware events with the call they belong to.
+type callState struct {
+	id       uint64
+	ctx      context.Context
+	method   string
+	peer     string
+	start    time.Time
+	deadline time.Time
+	traceID  string
+	isClient bool
+
+	// deadlineArrival is "expired" or "short" when the call arrived with
+	// little or no deadline budget left; see WithDeadlineBudget.
+	deadlineArrival string
+
+	// fields holds payload fields captured for WithPayloadFields, or nil.
+	fields *callFields
+	// metadata holds metadata captured for the metadata options, or nil.
+	metadata *callMetadata
+	// identity describes the peer's credentials; see WithPeerIdentity.
+	identity []slog.Attr
+	// unixPeer holds SO_PEERCRED credentials; see NewPeerCredListener.
+	unixPeer []slog.Attr
+	// caller describes the authenticated caller; see WithCallerExtractors.
+	caller []slog.Attr
+
+	// handlerPC points into the service method handling the call; see
+	// SourceHandler.
+	handlerPC atomic.Uintptr
+
+	// err is the error the handler or invoker returned; see callErrorAttrs.
+	err atomic.Pointer[error]
+
+	// entries counts the call's entries for insert IDs.
+	entries atomic.Uint64
+	// summary holds the started call entry; see WithCallSummaries.
+	summary *callSummary
+
+	// stopped is set while Logger.Stop may have canceled the call; see
+	// classifyCancel.
+	stopped atomic.Bool
+
+	stuckReported    atomic.Bool
+	deadlineReported atomic.Bool
+}
+
+type callStateKey struct{}
+
+// callFromContext returns the callState attached by the Logger's interceptors, if any.
+func callFromContext(ctx context.Context) (*callState, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	call, ok := ctx.Value(callStateKey{}).(*callState)
+	return call, ok
+}
+
+// beginCall creates the callState for fullMethod and attaches it to ctx.
+// Server calls are added to the Logger's call registry.
+func (l *Logger) beginCall(ctx context.Context, fullMethod string, isClient bool) (context.Context, *callState) {
+	call := &callState{
+		id:       nextCallID.Add(1),
+		method:   fullMethod,
+		start:    l.now(),
+		isClient: isClient,
+	}
+	if d, ok := ctx.Deadline(); ok {
+		call.deadline = d
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		call.peer = l.ipAnonymizer.address(p.Addr.String())
+	}
+	_, call.traceID, _, _, _ = slogcp.ExtractTraceSpan(ctx, "")
+	if l.summaries {
+		call.summary = &callSummary{}
+	}
+
+	if selected := l.payloadFields.forMethod(fullMethod); selected != nil {
+		call.fields = &callFields{selected: selected}
+	}
+	if !isClient {
+		call.deadlineArrival = l.deadlineBudget.classifyArrival(call)
+		if l.logPeerIdentity {
+			call.identity = l.peerIdentity(ctx)
+		}
+		call.unixPeer = peerCredAttrs(ctx)
+		call.caller = l.callerAttrs(ctx)
+	}
+	ctx = l.beginMetadata(ctx, call)
+
+	ctx = context.WithValue(ctx, callStateKey{}, call)
+	call.ctx = ctx
+	if !isClient && l.calls != nil {
+		l.calls.add(call)
+	}
+	return ctx, call
+}
+
+// endCall releases the bookkeeping created by beginCall.
+func (l *Logger) endCall(call *callState) {
+	if !call.isClient && l.calls != nil {
+		l.calls.remove(call)
+	}
+}
+
+// annotateCall adds call-scoped attributes to an entry logged for call at
+// time at and returns the possibly adjusted level. The termination level is
+// chosen before deadline budget escalation, which only raises levels, so a
+// call that overran its budget is not lowered to INFO by its cause.
+func (l *Logger) annotateCall(call *callState, at time.Time, msg string, level slog.Level, attrs []slog.Attr) (slog.Level, []slog.Attr) {
+	event, known := classifyEvent(msg)
+	if known && event == grpc_logging.FinishCall {
+		level, attrs = l.annotateTermination(call, level, l.callErrorAttrs(call, attrs))
+	}
+	if !call.isClient {
+		level, attrs = l.deadlineBudget.annotate(call, at, event, known, level, attrs)
+	}
+	if l.operations {
+		attrs = append(attrs, operationAttr(call, event, known))
+	}
+	return level, l.appendCallGroups(call, attrs)
+}
+
+// appendCallGroups appends the groups captured for call, such as payload
+// fields, metadata and peer identity, to attrs. Metadata and identity groups
+// are redacted here because Log redacts the middleware's fields before call
+// groups are added.
+func (l *Logger) appendCallGroups(call *callState, attrs []slog.Attr) []slog.Attr {
+	if call.fields != nil {
+		attrs = append(attrs, call.fields.attrs()...)
+	}
+	if call.metadata != nil {
+		attrs = append(attrs, l.redact(l.metadataAttrs(call))...)
+	}
+	for _, g := range []struct {
+		key   string
+		attrs []slog.Attr
+	}{
+		{PeerIdentityKey, call.identity},
+		{PeerCredKey, call.unixPeer},
+		{CallerKey, call.caller},
+	} {
+		if len(g.attrs) > 0 {
+			attrs = append(attrs, l.redact([]slog.Attr{{Key: g.key, Value: slog.GroupValue(g.attrs...)}})...)
+		}
+	}
+	return attrs
+}
+
+// classifyEvent maps a go-grpc-middleware log message to the event that produced it.
+// It reports false for messages the middleware does not emit for call events.
+func classifyEvent(msg string) (grpc_logging.LoggableEvent, bool) {
+	switch msg {
+	case "started call":
+		return grpc_logging.StartCall, true
+	case "finished call":
+		return grpc_logging.FinishCall, true
+	case "request received", "response received":
+		return grpc_logging.PayloadReceived, true
+	case "request sent", "response sent":
+		return grpc_logging.PayloadSent, true
+	default:
+		return 0, false
+	}
+}
+
+// UnaryServerInterceptor returns a unary server interceptor that logs through l.
+// Unlike passing l to grpc_logging.UnaryServerInterceptor directly, it also
+// maintains the per-call state used by adapter features such as the in-flight registry.
+func (l *Logger) UnaryServerInterceptor(opts ...grpc_logging.Option) grpc.UnaryServerInterceptor {
+	next := grpc_logging.UnaryServerInterceptor(l, opts...)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, call := l.beginCall(ctx, info.FullMethod, false)
+		defer l.endCall(call)
+		l.setHandler(call, info.Server)
+		l.captureRequest(call, req)
+		return next(ctx, req, info, l.fieldsHandler(call, errorHandler(call, handler)))
+	}
+}
+
+// StreamServerInterceptor returns a stream server interceptor that logs through l.
+// Like [Logger.UnaryServerInterceptor], it maintains per-call adapter state.
+func (l *Logger) StreamServerInterceptor(opts ...grpc_logging.Option) grpc.StreamServerInterceptor {
+	next := grpc_logging.StreamServerInterceptor(l, opts...)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, call := l.beginCall(ss.Context(), info.FullMethod, false)
+		defer l.endCall(call)
+		l.setHandler(call, srv)
+		if call.fields != nil {
+			ss = &fieldsServerStream{ServerStream: ss, l: l, call: call}
+		}
+		ss = l.metadataServerStreamFor(call, ss)
+		return next(srv, &contextServerStream{ServerStream: ss, ctx: ctx}, info, func(srv any, ss grpc.ServerStream) error {
+			err := handler(srv, ss)
+			call.setError(err)
+			return err
+		})
+	}
+}
+
+// UnaryClientInterceptor returns a unary client interceptor that logs through l.
+func (l *Logger) UnaryClientInterceptor(opts ...grpc_logging.Option) grpc.UnaryClientInterceptor {
+	next := grpc_logging.UnaryClientInterceptor(l, opts...)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		ctx, call := l.beginCall(ctx, method, true)
+		defer l.endCall(call)
+		l.captureRequest(call, req)
+		return next(ctx, method, req, reply, cc, l.fieldsInvoker(call, errorInvoker(call, l.metadataInvoker(call, invoker))), callOpts...)
+	}
+}
+
+// StreamClientInterceptor returns a stream client interceptor that logs through l.
+func (l *Logger) StreamClientInterceptor(opts ...grpc_logging.Option) grpc.StreamClientInterceptor {
+	next := grpc_logging.StreamClientInterceptor(l, opts...)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, call := l.beginCall(ctx, method, true)
+		defer l.endCall(call)
+		return next(ctx, desc, cc, method, l.fieldsStreamer(call, l.metadataStreamer(call, streamer)), callOpts...)
+	}
+}
+
+// errorHandler wraps handler to record the error it returns for call.
+func errorHandler(call *callState, handler grpc.UnaryHandler) grpc.UnaryHandler {
+	return func(ctx context.Context, req any) (any, error) {
+		resp, err := handler(ctx, req)
+		call.setError(err)
+		return resp, err
+	}
+}
+
+// errorInvoker wraps invoker to record the error it returns for call.
+func errorInvoker(call *callState, invoker grpc.UnaryInvoker) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		call.setError(err)
+		return err
+	}
+}
+
+// contextServerStream overrides the context of a wrapped grpc.ServerStream.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context carrying the adapter's call state.
+func (s *contextServerStream) Context() context.Context { return s.ctx }
+
+// splitFullMethod splits "/pkg.Service/Method" into its service and method parts.
+func splitFullMethod(fullMethod string) (service, method string) {
+	name := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "unknown", "unknown"
+}
@@ -0,0 +1,255 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// PayloadChunkKey is the group carrying one piece of a chunked payload:
+// its chunk group "id", the "field" it came from, its "index" and the chunk
+// "total", and the "data" itself.
+const PayloadChunkKey = "payload_chunk"
+
+// chunkedFields lists the attributes eligible for chunking.
+var chunkedFields = map[string]bool{
+	"grpc.request.content":  true,
+	"grpc.response.content": true,
+}
+
+var (
+	// processPrefix keeps generated IDs, such as chunk group and operation
+	// IDs, unique across processes.
+	processPrefix = func() string {
+		var b [6]byte
+		_, _ = rand.Read(b[:])
+		return hex.EncodeToString(b[:])
+	}()
+	nextChunkGroup atomic.Uint64
+)
+
+// WithPayloadChunking makes [NewLogger] split request and response payloads
+// whose encoded size exceeds chunkBytes across several entries instead of
+// logging them whole. Each entry repeats the other attributes, is logged with
+// the same context and so carries the same trace fields, and holds one piece
+// of the JSON-encoded payload under [PayloadChunkKey]. Use [ReassembleChunks]
+// to rebuild the payload. Chunk data is exempt from [SizeLimits]. A
+// non-positive chunkBytes disables chunking.
+//
+// chunkBytes bounds each chunk's data before the handler encodes it as a JSON
+// string, and that encoding can make it much larger: every quote and
+// backslash of the JSON payload doubles, and control characters grow sixfold.
+// Keep chunkBytes well under Cloud Logging's 256 KB entry limit, leaving room
+// for that growth and for the entry's other fields.
+func WithPayloadChunking(chunkBytes int) LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.chunkBytes = chunkBytes
+	}
+}
+
+// logChunked logs the attributes of e as chunk entries if any payload
+// attribute is larger than the chunk size, reporting whether it did.
+func (l *Logger) logChunked(e Event) bool {
+	var (
+		base    = make([]slog.Attr, 0, len(e.Attrs))
+		payload []slog.Attr
+	)
+	for _, a := range e.Attrs {
+		if chunkedFields[a.Key] && estimateAttr(a) > l.chunkBytes {
+			payload = append(payload, a)
+			continue
+		}
+		base = append(base, a)
+	}
+	if len(payload) == 0 {
+		return false
+	}
+	base = l.sizeLimits.limitAttrs(e.Message, base)
+	for p, a := range payload {
+		encoded, err := json.Marshal(valueToAny(a.Value.Resolve()))
+		if err != nil {
+			encoded, _ = json.Marshal(a.Value.String())
+		}
+		id := processPrefix + "-" + strconv.FormatUint(nextChunkGroup.Add(1), 10)
+		chunks := splitChunks(string(encoded), l.chunkBytes)
+		for i, data := range chunks {
+			chunk := slog.Group(PayloadChunkKey,
+				slog.String("id", id),
+				slog.String("field", a.Key),
+				slog.Int("index", i),
+				slog.Int("total", len(chunks)),
+				slog.String("data", data),
+			)
+			entry := append(base[:len(base):len(base)], chunk)
+			chunkInsertID(entry, p, i)
+			l.write(e.Context, e.Time, e.Level, e.Message, e.PC, entry)
+		}
+	}
+	return true
+}
+
+// splitChunks splits s into pieces of at most size bytes without splitting runes.
+func splitChunks(s string, size int) []string {
+	var chunks []string
+	for len(s) > size {
+		cut := size
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		if cut == 0 {
+			cut = size
+		}
+		chunks = append(chunks, s[:cut])
+		s = s[cut:]
+	}
+	return append(chunks, s)
+}
+
+// ChunkedPayload is a payload rebuilt by [ReassembleChunks].
+type ChunkedPayload struct {
+	// ID is the chunk group ID shared by the payload's entries.
+	ID string
+	// Field is the attribute the payload was logged under, such as
+	// "grpc.response.content".
+	Field string
+	// Value is the decoded payload.
+	Value any
+}
+
+// ReassembleChunks rebuilds the payloads split by [WithPayloadChunking] from
+// parsed JSON log lines, in the order their first chunk appears. Lines without
+// a [PayloadChunkKey] group are ignored, as are repeated chunks with the same
+// data, such as lines a shipper resent; an incomplete or inconsistent chunk
+// group is an error, as is a chunk whose index or total is not a whole
+// number or whose total exceeds the number of lines.
+//
+// Example:
+//
+//	var lines []map[string]any
+//	for scanner.Scan() {
+//		var line map[string]any
+//		if err := json.Unmarshal(scanner.Bytes(), &line); err == nil {
+//			lines = append(lines, line)
+//		}
+//	}
+//	payloads, err := slogcpadapter.ReassembleChunks(lines)
+func ReassembleChunks(lines []map[string]any) ([]ChunkedPayload, error) {
+	groups := make(map[string]*chunkGroup)
+	var order []string
+	for _, line := range lines {
+		raw, ok := line[PayloadChunkKey].(map[string]any)
+		if !ok {
+			continue
+		}
+		c, err := parseChunk(raw, len(lines))
+		if err != nil {
+			return nil, err
+		}
+		g, ok := groups[c.id]
+		if !ok {
+			g = &chunkGroup{field: c.field, data: make([]*string, c.total)}
+			groups[c.id] = g
+			order = append(order, c.id)
+		}
+		if err := g.add(c); err != nil {
+			return nil, fmt.Errorf("slogcpadapter: chunk group %s: %w", c.id, err)
+		}
+	}
+
+	out := make([]ChunkedPayload, 0, len(order))
+	for _, id := range order {
+		value, err := groups[id].decode()
+		if err != nil {
+			return nil, fmt.Errorf("slogcpadapter: chunk group %s: %w", id, err)
+		}
+		out = append(out, ChunkedPayload{ID: id, Field: groups[id].field, Value: value})
+	}
+	return out, nil
+}
+
+type chunk struct {
+	id, field, data string
+	index, total    int
+}
+
+// parseChunk reads one payload_chunk group decoded from JSON. A group cannot
+// have more chunks than maxTotal, the number of lines being reassembled.
+func parseChunk(raw map[string]any, maxTotal int) (chunk, error) {
+	id, _ := raw["id"].(string)
+	field, _ := raw["field"].(string)
+	data, okData := raw["data"].(string)
+	index, okIndex := chunkCount(raw["index"], maxTotal)
+	total, okTotal := chunkCount(raw["total"], maxTotal)
+	if id == "" || !okData || !okIndex || !okTotal || total < 1 || index >= total {
+		return chunk{}, fmt.Errorf("slogcpadapter: malformed %s %v", PayloadChunkKey, raw)
+	}
+	return chunk{id: id, field: field, data: data, index: index, total: total}, nil
+}
+
+// chunkCount reads a chunk index or total, reporting false unless v is a
+// whole number between 0 and limit.
+func chunkCount(v any, limit int) (int, bool) {
+	f, ok := v.(float64)
+	if !ok || f != math.Trunc(f) || f < 0 || f > float64(limit) {
+		return 0, false
+	}
+	return int(f), true
+}
+
+type chunkGroup struct {
+	field string
+	data  []*string
+}
+
+// add stores c in its slot. A repeat of a stored chunk, as written when a
+// log shipper resends lines, is ignored.
+func (g *chunkGroup) add(c chunk) error {
+	if c.total != len(g.data) {
+		return fmt.Errorf("chunk %d reports %d chunks, expected %d", c.index, c.total, len(g.data))
+	}
+	if prev := g.data[c.index]; prev != nil {
+		if *prev == c.data {
+			return nil
+		}
+		return fmt.Errorf("chunk %d seen twice with different data", c.index)
+	}
+	g.data[c.index] = &c.data
+	return nil
+}
+
+// decode joins the chunks and decodes the payload.
+func (g *chunkGroup) decode() (any, error) {
+	var b strings.Builder
+	for i, data := range g.data {
+		if data == nil {
+			return nil, fmt.Errorf("missing chunk %d of %d", i, len(g.data))
+		}
+		b.WriteString(*data)
+	}
+	var value any
+	if err := json.Unmarshal([]byte(b.String()), &value); err != nil {
+		return nil, fmt.Errorf("decoding payload: %w", err)
+	}
+	return value, nil
+}
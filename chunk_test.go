@@ -0,0 +1,138 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+)
+
+// parseLines decodes the JSON lines written to buf.
+func parseLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	for _, raw := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var line map[string]any
+		if err := json.Unmarshal([]byte(raw), &line); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", raw, err)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// TestPayloadChunkingRoundTrip verifies large payloads are split and can be reassembled.
+func TestPayloadChunkingRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(nil, WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))), WithPayloadChunking(64))
+
+	payload := slog.GroupValue(
+		slog.String("name", strings.Repeat("ü", 100)),
+		slog.Int("count", 3),
+	)
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "response sent",
+		"grpc.method", "Get",
+		"grpc.response.content", payload,
+	)
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "response sent", "grpc.response.content", "small")
+
+	lines := parseLines(t, &buf)
+	if len(lines) < 4 {
+		t.Fatalf("expected several chunk entries and one plain entry, got %d lines", len(lines))
+	}
+	for _, line := range lines[:len(lines)-1] {
+		if line["grpc.method"] != "Get" {
+			t.Fatalf("expected every chunk to repeat the other attributes, got %v", line)
+		}
+		if _, ok := line["grpc.response.content"]; ok {
+			t.Fatalf("expected the payload to be removed from chunk entries")
+		}
+	}
+	if lines[len(lines)-1]["grpc.response.content"] != "small" {
+		t.Fatalf("expected small payloads to be logged whole")
+	}
+
+	payloads, err := ReassembleChunks(lines)
+	if err != nil {
+		t.Fatalf("ReassembleChunks: %v", err)
+	}
+	want := map[string]any{"name": strings.Repeat("ü", 100), "count": 3.0}
+	if len(payloads) != 1 || payloads[0].Field != "grpc.response.content" || !reflect.DeepEqual(payloads[0].Value, want) {
+		t.Fatalf("expected reassembled payload %v, got %+v", want, payloads)
+	}
+
+	resent, err := ReassembleChunks(append(lines, lines[1:]...))
+	if err != nil {
+		t.Fatalf("ReassembleChunks with resent lines: %v", err)
+	}
+	if !reflect.DeepEqual(resent, payloads) {
+		t.Fatalf("expected resent lines to be ignored, got %+v", resent)
+	}
+}
+
+// TestReassembleChunksRejectsIncompleteGroups verifies missing, conflicting and inconsistent chunks are errors.
+func TestReassembleChunksRejectsIncompleteGroups(t *testing.T) {
+	chunkData := func(index, total float64, data string) map[string]any {
+		return map[string]any{PayloadChunkKey: map[string]any{"id": "g", "field": "f", "index": index, "total": total, "data": data}}
+	}
+	chunk := func(index, total float64) map[string]any { return chunkData(index, total, "{}") }
+	tests := []struct {
+		name  string
+		lines []map[string]any
+	}{
+		{"missing", []map[string]any{chunk(0, 2)}},
+		{"conflicting-duplicate", []map[string]any{chunkData(0, 2, "{"), chunkData(0, 2, "["), chunkData(1, 2, "}")}},
+		{"inconsistent-total", []map[string]any{chunk(0, 2), chunk(1, 3)}},
+		{"out-of-range", []map[string]any{chunk(2, 2)}},
+	}
+	for _, tt := range tests {
+		if _, err := ReassembleChunks(tt.lines); err == nil {
+			t.Fatalf("%s: expected error", tt.name)
+		}
+	}
+}
+
+// TestReassembleChunksRejectsMalformedChunks verifies chunk positions that are
+// not whole numbers, or totals larger than the input, are errors rather than
+// panics.
+func TestReassembleChunksRejectsMalformedChunks(t *testing.T) {
+	chunk := func(index, total any) map[string]any {
+		return map[string]any{PayloadChunkKey: map[string]any{"id": "g", "field": "f", "index": index, "total": total, "data": "{}"}}
+	}
+	tests := []struct {
+		name  string
+		index any
+		total any
+	}{
+		{"huge-total", 0.0, 1e19},
+		{"total-beyond-lines", 0.0, 2.0},
+		{"fractional-total", 0.0, 1.5},
+		{"fractional-index", 0.5, 1.0},
+		{"negative-index", -1.0, 1.0},
+		{"string-total", 0.0, "1"},
+	}
+	for _, tt := range tests {
+		if _, err := ReassembleChunks([]map[string]any{chunk(tt.index, tt.total)}); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// WithClock makes the Logger read the current time from now instead of
+// [time.Now], for entry timestamps, call start times and the durations the
+// adapter computes itself. It is meant for deterministic tests; times from
+// [time.Now] carry a monotonic reading, so durations stay correct across wall
+// clock changes. A nil now is ignored.
+func WithClock(now func() time.Time) LoggerOption {
+	return func(cfg *loggerConfig) {
+		if now != nil {
+			cfg.clock = now
+		}
+	}
+}
+
+// entryTime returns the timestamp for an entry logged with msg: the call's
+// start time for started call entries, and the current time otherwise, which
+// for finished call entries is when the call finished. Without call state, a
+// started call entry uses the middleware's grpc.start_time.
+func (l *Logger) entryTime(call *callState, msg string, attrs []slog.Attr) time.Time {
+	if msg != "started call" {
+		return l.now()
+	}
+	if call != nil {
+		return call.start
+	}
+	for _, a := range attrs {
+		if a.Key == "grpc.start_time" && a.Value.Kind() == slog.KindTime {
+			return a.Value.Time()
+		}
+	}
+	return l.now()
+}
+
+// enabled reports whether the underlying handler accepts entries at level.
+func (l *Logger) enabled(ctx context.Context, level slog.Level) bool {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return l.log.Handler().Enabled(ctx, level)
+}
+
+// write sends one record stamped with t and source pc to the underlying
+// handler, unless the handler is disabled at level. Log checks the level
+// before doing any work; the check here covers entries the adapter logs on
+// its own, such as in-flight dumps, and levels changed after that check.
+func (l *Logger) write(ctx context.Context, t time.Time, level slog.Level, msg string, pc uintptr, attrs []slog.Attr) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !l.enabled(ctx, level) {
+		return
+	}
+	r := slog.NewRecord(t, level, msg, pc)
+	r.AddAttrs(attrs...)
+	_ = l.log.Handler().Handle(ctx, r)
+}
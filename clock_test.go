@@ -0,0 +1,111 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// fakeClock returns a time that advances by step on every read.
+type fakeClock struct {
+	t    time.Time
+	step time.Duration
+}
+
+// Now returns the current fake time and advances it.
+func (c *fakeClock) Now() time.Time {
+	now := c.t
+	c.t = c.t.Add(c.step)
+	return now
+}
+
+// TestEntryTimestampsFollowCall verifies start entries carry the call's start
+// time and finish entries the finish time.
+func TestEntryTimestampsFollowCall(t *testing.T) {
+	rec := &recordingHandler{}
+	start := time.Date(2026, 5, 6, 7, 8, 9, 0, time.UTC)
+	clock := &fakeClock{t: start, step: 100 * time.Millisecond}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithClock(clock.Now))
+	interceptor := logger.UnaryServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.StartCall, grpc_logging.FinishCall))
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{}})
+
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Get"}, func(context.Context, any) (any, error) {
+		clock.t = clock.t.Add(2 * time.Second)
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rec.records) != 2 {
+		t.Fatalf("expected start and finish records, got %d", len(rec.records))
+	}
+	if got := rec.records[0].Time; !got.Equal(start) {
+		t.Fatalf("expected the start entry at the call start %v, got %v", start, got)
+	}
+	if got, want := rec.records[1].Time, start.Add(2100*time.Millisecond); !got.Equal(want) {
+		t.Fatalf("expected the finish entry at %v, got %v", want, got)
+	}
+}
+
+// TestEntryTimestampWithoutCallState verifies start entries fall back to the
+// middleware's grpc.start_time and other entries use the clock.
+func TestEntryTimestampWithoutCallState(t *testing.T) {
+	rec := &recordingHandler{}
+	now := time.Date(2026, 5, 6, 7, 8, 9, 0, time.UTC)
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithClock(func() time.Time { return now }))
+
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "started call", "grpc.start_time", "2026-05-06T07:08:00Z")
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "finished call")
+	if got, want := rec.records[0].Time, now.Add(-9*time.Second); !got.Equal(want) {
+		t.Fatalf("expected the start entry at grpc.start_time %v, got %v", want, got)
+	}
+	if got := rec.records[1].Time; !got.Equal(now) {
+		t.Fatalf("expected the finish entry at %v, got %v", now, got)
+	}
+}
+
+// TestLoggerRespectsHandlerEnabled verifies disabled levels never reach Handle
+// and are dropped before the adapter processes them.
+func TestLoggerRespectsHandlerEnabled(t *testing.T) {
+	var (
+		buf  bytes.Buffer
+		seen []string
+	)
+	logger := NewLogger(nil,
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))),
+		WithTransformers(func(e *Event) bool {
+			seen = append(seen, e.Message)
+			return true
+		}),
+	)
+
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "quiet")
+	logger.Log(context.Background(), grpc_logging.LevelWarn, "loud")
+	if got := buf.String(); bytes.Contains(buf.Bytes(), []byte("quiet")) || !bytes.Contains(buf.Bytes(), []byte("loud")) {
+		t.Fatalf("expected only the warning to be written, got %s", got)
+	}
+	if len(seen) != 1 || seen[0] != "loud" {
+		t.Fatalf("expected only the warning to be processed, got %v", seen)
+	}
+}
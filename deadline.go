@@ -0,0 +1,114 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"log/slog"
+	"time"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+)
+
+const (
+	deadlineArrivalExpired = "expired"
+	deadlineArrivalShort   = "short"
+)
+
+// DeadlineBudget configures how server calls are judged against the caller's deadline.
+// The budget of a call is the time between its arrival and its deadline; calls
+// without a deadline are not tracked.
+type DeadlineBudget struct {
+	// WarnRatio raises finish entries to at least WARN once the call has used
+	// this fraction of its budget (for example 0.8). Zero disables the check.
+	WarnRatio float64
+	// ErrorRatio raises finish entries to at least ERROR once the call has used
+	// this fraction of its budget (for example 0.95). Zero disables the check.
+	ErrorRatio float64
+	// MinDeadline flags calls that arrive with less than this much time left.
+	// Zero only flags calls whose deadline had already passed on arrival.
+	MinDeadline time.Duration
+}
+
+// WithDeadlineBudget makes the Logger's server interceptors track deadline budgets.
+// Finish entries gain a deadline_budget_used ratio and are escalated according to
+// budget. Every entry of a call that arrived with an expired or short deadline
+// carries deadline_arrival ("expired" or "short") and deadline_remaining_ms.
+//
+// Example:
+//
+//	adapter := slogcpadapter.NewLogger(handler, slogcpadapter.WithDeadlineBudget(slogcpadapter.DeadlineBudget{
+//		WarnRatio:   0.8,
+//		ErrorRatio:  0.95,
+//		MinDeadline: 50 * time.Millisecond,
+//	}))
+func WithDeadlineBudget(budget DeadlineBudget) LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.deadlineBudget = &budget
+	}
+}
+
+// classifyArrival reports whether call arrived with an expired or short deadline.
+// It returns "" when b is nil, the call has no deadline, or the budget is adequate.
+func (b *DeadlineBudget) classifyArrival(call *callState) string {
+	if b == nil || call.deadline.IsZero() {
+		return ""
+	}
+	remaining := call.deadline.Sub(call.start)
+	switch {
+	case remaining <= 0:
+		return deadlineArrivalExpired
+	case b.MinDeadline > 0 && remaining < b.MinDeadline:
+		return deadlineArrivalShort
+	default:
+		return ""
+	}
+}
+
+// annotate adds deadline budget attributes to an entry for call logged at now
+// and escalates finish entries whose budget use crossed a configured ratio.
+func (b *DeadlineBudget) annotate(call *callState, now time.Time, event grpc_logging.LoggableEvent, known bool, level slog.Level, attrs []slog.Attr) (slog.Level, []slog.Attr) {
+	if b == nil || call.deadline.IsZero() {
+		return level, attrs
+	}
+	if call.deadlineArrival != "" {
+		attrs = append(attrs,
+			slog.String("deadline_arrival", call.deadlineArrival),
+			slog.Float64("deadline_remaining_ms", durationMillis(call.deadline.Sub(call.start))),
+		)
+	}
+	if !known || event != grpc_logging.FinishCall {
+		return level, attrs
+	}
+
+	used := budgetUsed(call.start, call.deadline, now)
+	attrs = append(attrs, slog.Float64("deadline_budget_used", used))
+	switch {
+	case b.ErrorRatio > 0 && used >= b.ErrorRatio:
+		level = max(level, slog.LevelError)
+	case b.WarnRatio > 0 && used >= b.WarnRatio:
+		level = max(level, slog.LevelWarn)
+	}
+	return level, attrs
+}
+
+// budgetUsed returns the fraction of the start-to-deadline budget consumed at now.
+// Calls that arrived with no budget left report 1 or more.
+func budgetUsed(start, deadline, now time.Time) float64 {
+	budget := deadline.Sub(start)
+	if budget <= 0 {
+		return 1
+	}
+	return float64(now.Sub(start)) / float64(budget)
+}
@@ -0,0 +1,137 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+)
+
+// TestDeadlineBudgetEscalatesFinishEntries verifies ratio thresholds on finish entries.
+func TestDeadlineBudgetEscalatesFinishEntries(t *testing.T) {
+	budget := &DeadlineBudget{WarnRatio: 0.8, ErrorRatio: 0.95}
+	start := time.Now()
+	now := start.Add(850 * time.Millisecond)
+
+	tests := []struct {
+		name     string
+		deadline time.Time
+		want     slog.Level
+	}{
+		{"plenty-left", start.Add(time.Hour), slog.LevelInfo},
+		{"warn", start.Add(time.Second), slog.LevelWarn},
+		{"error", start.Add(time.Nanosecond), slog.LevelError},
+	}
+	for _, tt := range tests {
+		call := &callState{start: start, deadline: tt.deadline}
+		level, attrs := budget.annotate(call, now, grpc_logging.FinishCall, true, slog.LevelInfo, nil)
+		if level != tt.want {
+			t.Fatalf("%s: expected level %v, got %v", tt.name, tt.want, level)
+		}
+		if !hasAttr(attrs, "deadline_budget_used") {
+			t.Fatalf("%s: expected deadline_budget_used attr", tt.name)
+		}
+	}
+}
+
+// TestDeadlineBudgetIgnoresCallsWithoutDeadline verifies untracked calls pass through unchanged.
+func TestDeadlineBudgetIgnoresCallsWithoutDeadline(t *testing.T) {
+	budget := &DeadlineBudget{WarnRatio: 0.1}
+	call := &callState{start: time.Now()}
+	level, attrs := budget.annotate(call, time.Now(), grpc_logging.FinishCall, true, slog.LevelDebug, nil)
+	if level != slog.LevelDebug || len(attrs) != 0 {
+		t.Fatalf("expected no change, got %v %v", level, attrs)
+	}
+
+	var disabled *DeadlineBudget
+	if got := disabled.classifyArrival(&callState{deadline: time.Now()}); got != "" {
+		t.Fatalf("expected nil budget to classify nothing, got %q", got)
+	}
+}
+
+// TestDeadlineBudgetClassifiesArrival verifies expired and short deadlines are flagged.
+func TestDeadlineBudgetClassifiesArrival(t *testing.T) {
+	budget := &DeadlineBudget{MinDeadline: 50 * time.Millisecond}
+	start := time.Now()
+
+	tests := []struct {
+		name     string
+		deadline time.Time
+		want     string
+	}{
+		{"expired", start.Add(-time.Millisecond), deadlineArrivalExpired},
+		{"short", start.Add(10 * time.Millisecond), deadlineArrivalShort},
+		{"adequate", start.Add(time.Second), ""},
+	}
+	for _, tt := range tests {
+		if got := budget.classifyArrival(&callState{start: start, deadline: tt.deadline}); got != tt.want {
+			t.Fatalf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+// TestDeadlineBudgetThroughInterceptor verifies an expired arrival is flagged on start and finish entries.
+func TestDeadlineBudgetThroughInterceptor(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithDeadlineBudget(DeadlineBudget{ErrorRatio: 0.95}))
+	interceptor := logger.UnaryServerInterceptor()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	info := &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Get"}
+	_, _ = interceptor(ctx, nil, info, func(context.Context, any) (any, error) { return nil, nil })
+
+	if len(rec.records) != 2 {
+		t.Fatalf("expected start and finish records, got %d", len(rec.records))
+	}
+	for _, r := range rec.records {
+		if got := collectAttrs(r)["deadline_arrival"]; got != deadlineArrivalExpired {
+			t.Fatalf("%s: expected expired arrival flag, got %v", r.Message, got)
+		}
+	}
+	finish := rec.records[1]
+	if finish.Level != slog.LevelError {
+		t.Fatalf("expected finish entry escalated to error, got %v", finish.Level)
+	}
+	if got := collectAttrs(finish)["deadline_budget_used"]; got != float64(1) {
+		t.Fatalf("expected budget used of 1, got %v", got)
+	}
+}
+
+// TestBudgetUsed verifies the budget ratio computation.
+func TestBudgetUsed(t *testing.T) {
+	start := time.Unix(0, 0)
+	if got := budgetUsed(start, start.Add(time.Second), start.Add(500*time.Millisecond)); got != 0.5 {
+		t.Fatalf("expected 0.5, got %v", got)
+	}
+	if got := budgetUsed(start, start, start.Add(time.Second)); got != 1 {
+		t.Fatalf("expected 1 for zero budget, got %v", got)
+	}
+}
+
+// hasAttr reports whether attrs contains key at the top level.
+func hasAttr(attrs []slog.Attr, key string) bool {
+	for _, a := range attrs {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// registryShards is the number of independently locked shards in a callRegistry.
+// Striping keeps concurrent call starts and finishes from contending on one mutex.
+const registryShards = 32
+
+// InFlightCall is a snapshot of one server RPC that has started but not finished.
+type InFlightCall struct {
+	// FullMethod is the gRPC method, for example "/pkg.Service/Method".
+	FullMethod string
+	// Peer is the remote address reported by the transport, if known.
+	Peer string
+	// Start is when the adapter's interceptor first saw the call.
+	Start time.Time
+	// Deadline is the caller's deadline, or the zero time when none was set.
+	Deadline time.Time
+	// TraceID is the OpenTelemetry trace ID from the call context, if any.
+	TraceID string
+}
+
+// callRegistry tracks in-flight server calls across lock-striped shards.
+type callRegistry struct {
+	shards [registryShards]registryShard
+}
+
+type registryShard struct {
+	mu    sync.Mutex
+	calls map[uint64]*callState
+}
+
+// newCallRegistry returns an empty callRegistry.
+func newCallRegistry() *callRegistry {
+	r := &callRegistry{}
+	for i := range r.shards {
+		r.shards[i].calls = make(map[uint64]*callState)
+	}
+	return r
+}
+
+// shard returns the shard responsible for id.
+func (r *callRegistry) shard(id uint64) *registryShard {
+	return &r.shards[id%registryShards]
+}
+
+// add records call as in flight.
+func (r *callRegistry) add(call *callState) {
+	s := r.shard(call.id)
+	s.mu.Lock()
+	s.calls[call.id] = call
+	s.mu.Unlock()
+}
+
+// remove forgets call once it has finished.
+func (r *callRegistry) remove(call *callState) {
+	s := r.shard(call.id)
+	s.mu.Lock()
+	delete(s.calls, call.id)
+	s.mu.Unlock()
+}
+
+// snapshot returns the in-flight calls ordered by start time.
+func (r *callRegistry) snapshot() []*callState {
+	var calls []*callState
+	for i := range r.shards {
+		s := &r.shards[i]
+		s.mu.Lock()
+		for _, call := range s.calls {
+			calls = append(calls, call)
+		}
+		s.mu.Unlock()
+	}
+	sort.Slice(calls, func(i, j int) bool {
+		if calls[i].start.Equal(calls[j].start) {
+			return calls[i].id < calls[j].id
+		}
+		return calls[i].start.Before(calls[j].start)
+	})
+	return calls
+}
+
+// WithInFlightRegistry makes the Logger's server interceptors track in-flight calls.
+// The registry backs [Logger.InFlightCalls], [Logger.DumpInFlightCalls] and [Logger.StartWatchdog].
+// It only sees calls that go through the Logger's own interceptor methods
+// or the package-level interceptor helpers.
+func WithInFlightRegistry() LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.inFlightRegistry = true
+	}
+}
+
+// InFlightCalls returns a snapshot of the server calls currently in progress, oldest first.
+// It returns nil when the Logger was created without [WithInFlightRegistry].
+func (l *Logger) InFlightCalls() []InFlightCall {
+	if l == nil || l.registry == nil {
+		return nil
+	}
+	calls := l.registry.snapshot()
+	out := make([]InFlightCall, 0, len(calls))
+	for _, call := range calls {
+		out = append(out, InFlightCall{
+			FullMethod: call.method,
+			Peer:       call.peer,
+			Start:      call.start,
+			Deadline:   call.deadline,
+			TraceID:    call.traceID,
+		})
+	}
+	return out
+}
+
+// DumpInFlightCalls logs one entry per in-flight server call and returns how many were logged.
+// Each entry is written with the call's own context so slogcp attaches its trace fields.
+// It is intended for SIGQUIT handlers or admin RPCs; ctx only bounds the dump itself.
+//
+// Example:
+//
+//	sigs := make(chan os.Signal, 1)
+//	signal.Notify(sigs, syscall.SIGQUIT)
+//	go func() {
+//		for range sigs {
+//			adapter.DumpInFlightCalls(context.Background())
+//		}
+//	}()
+func (l *Logger) DumpInFlightCalls(ctx context.Context) int {
+	if l == nil || l.registry == nil || l.log == nil {
+		return 0
+	}
+	now := l.now()
+	n := 0
+	for _, call := range l.registry.snapshot() {
+		if ctx.Err() != nil {
+			break
+		}
+		l.logCall(call, slog.LevelInfo, "in-flight call", now)
+		n++
+	}
+	return n
+}
+
+// StartWatchdog scans the in-flight registry every interval and logs a warning for
+// calls that have run longer than threshold or past their deadline. Each call is
+// reported at most once per condition. A non-positive threshold disables the
+// duration check. The returned function stops the watchdog.
+// StartWatchdog is a no-op when the Logger was created without [WithInFlightRegistry].
+//
+// Example:
+//
+//	adapter := slogcpadapter.NewLogger(handler, slogcpadapter.WithInFlightRegistry())
+//	stop := adapter.StartWatchdog(30*time.Second, 5*time.Second)
+//	defer stop()
+func (l *Logger) StartWatchdog(threshold, interval time.Duration) (stop func()) {
+	if l == nil || l.registry == nil || l.log == nil || interval <= 0 {
+		return func() {}
+	}
+	done := make(chan struct{})
+	var once sync.Once
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				l.checkStuckCalls(threshold, l.now())
+			}
+		}
+	}()
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// checkStuckCalls logs in-flight calls that exceed threshold or their deadline at now.
+func (l *Logger) checkStuckCalls(threshold time.Duration, now time.Time) {
+	for _, call := range l.registry.snapshot() {
+		if threshold > 0 && now.Sub(call.start) >= threshold && !call.stuckReported.Swap(true) {
+			l.logCall(call, slog.LevelWarn, "call exceeded stuck threshold", now,
+				slog.Float64("stuck_threshold_ms", durationMillis(threshold)))
+		}
+		if !call.deadline.IsZero() && now.After(call.deadline) && !call.deadlineReported.Swap(true) {
+			l.logCall(call, slog.LevelWarn, "call still running past its deadline", now)
+		}
+	}
+}
+
+// logCall writes one entry describing call as observed at now.
+func (l *Logger) logCall(call *callState, level slog.Level, msg string, now time.Time, extra ...slog.Attr) {
+	service, method := splitFullMethod(call.method)
+	attrs := make([]slog.Attr, 0, 7+len(extra))
+	attrs = append(attrs,
+		slog.String("grpc.component", "server"),
+		slog.String("grpc.service", service),
+		slog.String("grpc.method", method),
+		slog.Time("grpc.start_time", call.start),
+		slog.Float64("grpc.elapsed_ms", durationMillis(now.Sub(call.start))),
+	)
+	if call.peer != "" {
+		attrs = append(attrs, slog.String("peer.address", call.peer))
+	}
+	if !call.deadline.IsZero() {
+		attrs = append(attrs, slog.Time("grpc.request.deadline", call.deadline))
+	}
+	attrs = append(attrs, extra...)
+	if l.operations {
+		attrs = append(attrs, operationAttr(call, 0, false))
+	}
+	attrs = l.appendInsertID(call, msg, attrs)
+	l.write(call.ctx, now, level, msg, l.sourcePC(call), attrs)
+}
+
+// durationMillis converts d to fractional milliseconds.
+func durationMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,168 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// TestInFlightRegistryTracksUnaryCalls verifies that calls appear in the registry only while running.
+func TestInFlightRegistryTracksUnaryCalls(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithInFlightRegistry())
+	interceptor := logger.UnaryServerInterceptor(grpc_logging.WithLogOnEvents())
+
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4242}})
+
+	var during []InFlightCall
+	info := &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Get"}
+	_, err := interceptor(ctx, nil, info, func(context.Context, any) (any, error) {
+		during = logger.InFlightCalls()
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(during) != 1 {
+		t.Fatalf("expected 1 in-flight call during handler, got %d", len(during))
+	}
+	got := during[0]
+	if got.FullMethod != "/pkg.Svc/Get" {
+		t.Fatalf("unexpected method: %q", got.FullMethod)
+	}
+	if got.Peer != "10.0.0.1:4242" {
+		t.Fatalf("unexpected peer: %q", got.Peer)
+	}
+	if !got.Deadline.Equal(deadline) {
+		t.Fatalf("unexpected deadline: %v", got.Deadline)
+	}
+	if after := logger.InFlightCalls(); len(after) != 0 {
+		t.Fatalf("expected registry to be empty after the call, got %d", len(after))
+	}
+}
+
+// TestInFlightRegistryDisabledByDefault verifies that registry APIs are inert without the option.
+func TestInFlightRegistryDisabledByDefault(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)))
+
+	if calls := logger.InFlightCalls(); calls != nil {
+		t.Fatalf("expected nil snapshot, got %v", calls)
+	}
+	if n := logger.DumpInFlightCalls(context.Background()); n != 0 {
+		t.Fatalf("expected no dumped calls, got %d", n)
+	}
+	logger.StartWatchdog(time.Second, time.Second)()
+}
+
+// TestDumpInFlightCallsLogsEachCall verifies the dump emits one entry per running call.
+func TestDumpInFlightCallsLogsEachCall(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithInFlightRegistry())
+
+	_, first := logger.beginCall(context.Background(), "/pkg.Svc/A", false)
+	_, second := logger.beginCall(context.Background(), "/pkg.Svc/B", false)
+	defer logger.endCall(first)
+	defer logger.endCall(second)
+
+	if n := logger.DumpInFlightCalls(context.Background()); n != 2 {
+		t.Fatalf("expected 2 dumped calls, got %d", n)
+	}
+	if len(rec.records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(rec.records))
+	}
+	attrs := collectAttrs(rec.records[0])
+	if rec.records[0].Message != "in-flight call" {
+		t.Fatalf("unexpected message: %q", rec.records[0].Message)
+	}
+	if attrs["grpc.service"] != "pkg.Svc" || attrs["grpc.method"] != "A" {
+		t.Fatalf("unexpected method attrs: %v", attrs)
+	}
+}
+
+// TestWatchdogReportsStuckAndExpiredCallsOnce verifies threshold and deadline reporting is deduplicated.
+func TestWatchdogReportsStuckAndExpiredCallsOnce(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithInFlightRegistry())
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second))
+	defer cancel()
+	_, call := logger.beginCall(ctx, "/pkg.Svc/Slow", false)
+	defer logger.endCall(call)
+
+	later := call.start.Add(2 * time.Second)
+	logger.checkStuckCalls(time.Second, later)
+	logger.checkStuckCalls(time.Second, later.Add(time.Second))
+
+	if len(rec.records) != 2 {
+		t.Fatalf("expected 2 watchdog records, got %d", len(rec.records))
+	}
+	if rec.records[0].Message != "call exceeded stuck threshold" {
+		t.Fatalf("unexpected first message: %q", rec.records[0].Message)
+	}
+	if rec.records[1].Message != "call still running past its deadline" {
+		t.Fatalf("unexpected second message: %q", rec.records[1].Message)
+	}
+	for _, r := range rec.records {
+		if r.Level != slog.LevelWarn {
+			t.Fatalf("expected warn level, got %v", r.Level)
+		}
+	}
+}
+
+// TestStreamServerInterceptorTracksCalls verifies the stream interceptor exposes call state via the stream context.
+func TestStreamServerInterceptorTracksCalls(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithInFlightRegistry())
+	interceptor := logger.StreamServerInterceptor(grpc_logging.WithLogOnEvents())
+
+	info := &grpc.StreamServerInfo{FullMethod: "/pkg.Svc/Watch", IsServerStream: true}
+	err := interceptor(nil, &fakeServerStream{ctx: context.Background()}, info, func(_ any, ss grpc.ServerStream) error {
+		if _, ok := callFromContext(ss.Context()); !ok {
+			t.Fatalf("expected call state on stream context")
+		}
+		if n := len(logger.InFlightCalls()); n != 1 {
+			t.Fatalf("expected 1 in-flight call, got %d", n)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(logger.InFlightCalls()); n != 0 {
+		t.Fatalf("expected registry to be empty, got %d", n)
+	}
+}
+
+// fakeServerStream is a minimal grpc.ServerStream for interceptor tests.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the stream's context.
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
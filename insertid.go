@@ -0,0 +1,89 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"log/slog"
+	"strconv"
+	"sync/atomic"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+)
+
+// InsertIDKey is the attribute Cloud Logging reads into a LogEntry's
+// insertId, which it uses to drop duplicate writes of the same entry.
+const InsertIDKey = "logging.googleapis.com/insertId"
+
+// nextInsertID numbers entries that do not belong to a tracked call.
+var nextInsertID atomic.Uint64
+
+// WithInsertIDs makes the Logger give every entry an [InsertIDKey], so lines a
+// log shipper resends after a crash collapse into one entry at ingestion.
+// Entries of a call tracked by the Logger's interceptors get
+// "<call ID>-<event>-<sequence>", where event is start, finish, recv, sent or
+// log and the sequence counts the call's entries; chunks of a payload add
+// their position. Other entries get an ID unique to the process.
+func WithInsertIDs() LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.insertIDs = true
+	}
+}
+
+// appendInsertID adds an insert ID for an entry logged with msg for call,
+// which may be nil.
+func (l *Logger) appendInsertID(call *callState, msg string, attrs []slog.Attr) []slog.Attr {
+	if !l.insertIDs {
+		return attrs
+	}
+	return append(attrs, slog.String(InsertIDKey, insertID(call, eventName(msg))))
+}
+
+// insertID returns the next insert ID for call, or a process-unique one when
+// call is nil.
+func insertID(call *callState, event string) string {
+	if call == nil {
+		return processPrefix + "-" + strconv.FormatUint(nextInsertID.Add(1), 10)
+	}
+	return call.operationID() + "-" + event + "-" + strconv.FormatUint(call.entries.Add(1), 10)
+}
+
+// eventName names the event that produced msg for insert IDs.
+func eventName(msg string) string {
+	event, known := classifyEvent(msg)
+	if !known {
+		return "log"
+	}
+	switch event {
+	case grpc_logging.StartCall:
+		return "start"
+	case grpc_logging.FinishCall:
+		return "finish"
+	case grpc_logging.PayloadReceived:
+		return "recv"
+	default:
+		return "sent"
+	}
+}
+
+// chunkInsertID gives entry, one chunk of a split payload, an insert ID of
+// its own by suffixing the entry's ID with the payload and chunk positions.
+func chunkInsertID(entry []slog.Attr, payload, chunk int) {
+	for i, a := range entry {
+		if a.Key == InsertIDKey {
+			entry[i].Value = slog.StringValue(a.Value.String() + "-" + strconv.Itoa(payload) + "." + strconv.Itoa(chunk))
+			return
+		}
+	}
+}
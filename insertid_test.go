@@ -0,0 +1,83 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// TestInsertIDsForCallEntries verifies call entries are numbered by call, event and sequence.
+func TestInsertIDsForCallEntries(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithInsertIDs())
+	interceptor := logger.UnaryServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.StartCall, grpc_logging.FinishCall))
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{}})
+
+	var callID string
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Get"}, func(ctx context.Context, _ any) (any, error) {
+		call, _ := callFromContext(ctx)
+		callID = call.operationID()
+		logger.Log(ctx, grpc_logging.LevelInfo, "handler note")
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{callID + "-start-1", callID + "-log-2", callID + "-finish-3"}
+	if len(rec.records) != len(want) {
+		t.Fatalf("expected %d records, got %d", len(want), len(rec.records))
+	}
+	for i, r := range rec.records {
+		if got := collectAttrs(r)[InsertIDKey]; got != want[i] {
+			t.Errorf("record %d: expected insert ID %q, got %v", i, want[i], got)
+		}
+	}
+}
+
+// TestInsertIDsFallbackAndChunks verifies entries outside calls get unique IDs
+// and each chunk of a payload gets its own.
+func TestInsertIDsFallbackAndChunks(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(nil, WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))), WithInsertIDs(), WithPayloadChunking(32))
+
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "note")
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "response sent", "grpc.response.content", strings.Repeat("x", 80))
+
+	seen := make(map[string]bool)
+	lines := parseLines(t, &buf)
+	if len(lines) < 3 {
+		t.Fatalf("expected a plain entry and several chunks, got %d lines", len(lines))
+	}
+	for _, line := range lines {
+		id, _ := line[InsertIDKey].(string)
+		if !strings.HasPrefix(id, processPrefix+"-") {
+			t.Fatalf("expected a process-unique insert ID, got %q", id)
+		}
+		if seen[id] {
+			t.Fatalf("expected unique insert IDs, %q repeated", id)
+		}
+		seen[id] = true
+	}
+}
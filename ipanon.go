@@ -0,0 +1,124 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"log/slog"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// forwardedMetadata lists the metadata keys that carry client addresses.
+var forwardedMetadata = map[string]bool{"x-forwarded-for": true, "x-real-ip": true}
+
+// WithIPTruncation makes the Logger truncate client IP addresses before they
+// are logged, zeroing the last octet of IPv4 addresses and the last 80 bits of
+// IPv6 addresses. It applies to peer.address on every entry, including
+// in-flight and deadline warnings and [Logger.InFlightCalls], and to logged
+// X-Forwarded-For and X-Real-IP metadata. Ports and values that are not IP
+// addresses, such as Unix socket paths, are kept.
+func WithIPTruncation() LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.ipAnonymizer = &ipAnonymizer{}
+	}
+}
+
+// WithIPHashing is like [WithIPTruncation] but replaces each IP address, and
+// its port, with a keyed HMAC digest of the address, so requests from one
+// client can still be correlated. An empty key is ignored.
+func WithIPHashing(hmacKey []byte) LoggerOption {
+	return func(cfg *loggerConfig) {
+		if len(hmacKey) > 0 {
+			cfg.ipAnonymizer = &ipAnonymizer{key: hmacKey}
+		}
+	}
+}
+
+// ipAnonymizer rewrites IP addresses, truncating them without a key and
+// hashing them with one. A nil ipAnonymizer leaves addresses unchanged.
+type ipAnonymizer struct {
+	key []byte
+}
+
+// address anonymizes a bare IP or host:port address.
+func (a *ipAnonymizer) address(s string) string {
+	if a == nil {
+		return s
+	}
+	if ip, err := netip.ParseAddr(s); err == nil {
+		return a.ip(ip)
+	}
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return s
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return s
+	}
+	if a.key != nil {
+		return a.ip(ip)
+	}
+	return net.JoinHostPort(a.ip(ip), port)
+}
+
+// ip anonymizes one address.
+func (a *ipAnonymizer) ip(ip netip.Addr) string {
+	ip = ip.Unmap().WithZone("")
+	if a.key != nil {
+		return hmacDigest(a.key, ip.String())
+	}
+	bits := 24
+	if ip.Is6() {
+		bits = 48
+	}
+	prefix, _ := ip.Prefix(bits)
+	return prefix.Addr().String()
+}
+
+// list anonymizes each address in a comma-separated list.
+func (a *ipAnonymizer) list(s string) string {
+	parts := strings.Split(s, ",")
+	for i, p := range parts {
+		parts[i] = a.address(strings.TrimSpace(p))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// anonymizePeer rewrites the peer.address attribute in attrs.
+func (a *ipAnonymizer) anonymizePeer(attrs []slog.Attr) []slog.Attr {
+	if a == nil {
+		return attrs
+	}
+	for i, attr := range attrs {
+		if attr.Key == "peer.address" {
+			attrs[i].Value = slog.StringValue(a.address(attr.Value.Resolve().String()))
+		}
+	}
+	return attrs
+}
+
+// metadataValues anonymizes the values of forwarding metadata keys.
+func (a *ipAnonymizer) metadataValues(key string, values []string) []string {
+	if a == nil || !forwardedMetadata[key] {
+		return values
+	}
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = a.list(v)
+	}
+	return out
+}
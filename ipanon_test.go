@@ -0,0 +1,96 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"testing"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// TestIPAnonymizerAddress verifies truncation and hashing of each address form.
+func TestIPAnonymizerAddress(t *testing.T) {
+	key := []byte("ip-key")
+	tests := []struct {
+		in, truncated, hashed string
+	}{
+		{"203.0.113.77:4242", "203.0.113.0:4242", hmacDigest(key, "203.0.113.77")},
+		{"203.0.113.77", "203.0.113.0", hmacDigest(key, "203.0.113.77")},
+		{"[2001:db8:1234:5678:9abc::1]:443", "[2001:db8:1234::]:443", hmacDigest(key, "2001:db8:1234:5678:9abc::1")},
+		{"::ffff:198.51.100.9", "198.51.100.0", hmacDigest(key, "198.51.100.9")},
+		{"fe80::1%eth0", "fe80::", hmacDigest(key, "fe80::1")},
+		{"@pid=42", "@pid=42", "@pid=42"},
+		{"/run/app.sock", "/run/app.sock", "/run/app.sock"},
+		{"localhost:80", "localhost:80", "localhost:80"},
+	}
+	truncate, hash := &ipAnonymizer{}, &ipAnonymizer{key: key}
+	for _, tt := range tests {
+		if got := truncate.address(tt.in); got != tt.truncated {
+			t.Errorf("truncate(%q) = %q, want %q", tt.in, got, tt.truncated)
+		}
+		if got := hash.address(tt.in); got != tt.hashed {
+			t.Errorf("hash(%q) = %q, want %q", tt.in, got, tt.hashed)
+		}
+	}
+	if got := (*ipAnonymizer)(nil).address("203.0.113.77:1"); got != "203.0.113.77:1" {
+		t.Errorf("nil anonymizer changed the address to %q", got)
+	}
+}
+
+// TestIPTruncationAcrossCallPaths verifies peer.address, the in-flight
+// registry and forwarded metadata are all truncated.
+func TestIPTruncationAcrossCallPaths(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)),
+		WithIPTruncation(),
+		WithInFlightRegistry(),
+		WithMetadataAllowlist("x-forwarded-for"),
+	)
+	interceptor := logger.StreamServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.FinishCall))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "198.51.100.23, 2001:db8::7"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 1, 2, 3), Port: 5000}})
+
+	var inFlight []InFlightCall
+	ss := &fakeServerStream{ctx: ctx}
+	err := interceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: "/pkg.Svc/Watch"}, func(any, grpc.ServerStream) error {
+		inFlight = logger.InFlightCalls()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(inFlight) != 1 || inFlight[0].Peer != "10.1.2.0:5000" {
+		t.Fatalf("expected truncated in-flight peer, got %+v", inFlight)
+	}
+	if len(rec.records) == 0 {
+		t.Fatal("expected call entries")
+	}
+	for _, r := range rec.records {
+		if got := collectAttrs(r)["peer.address"]; got != "10.1.2.0:5000" {
+			t.Fatalf("expected truncated peer.address on %q, got %v", r.Message, got)
+		}
+		md := valueToAny(attrValue(t, r, RequestMetadataKey)).(map[string]any)
+		if got := md["x-forwarded-for"]; got != "198.51.100.0, 2001:db8::" {
+			t.Fatalf("expected truncated x-forwarded-for, got %v", got)
+		}
+	}
+}
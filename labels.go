@@ -0,0 +1,221 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pjscruggs/slogcp"
+)
+
+// Cloud Logging limits on the labels of one entry.
+const (
+	MaxLabels          = 64
+	MaxLabelKeyBytes   = 512
+	MaxLabelValueBytes = 64 << 10
+)
+
+// WithLabelFields makes [Logger.Log] also emit the named fields as string
+// labels under [slogcp.LabelsGroup], which Cloud Logging indexes far more
+// cheaply than jsonPayload. A field is a top-level attribute key such as
+// "grpc.method" or a dotted path into a group such as "caller.sub" or
+// "grpc.request.metadata.x-tenant". The option may be given several times.
+//
+// Label keys are the field paths with characters other than letters, digits
+// and "._/-" replaced by "_". Values are stringified: numbers and booleans
+// in their usual form, durations like "1.5s", times in RFC 3339, byte slices
+// in base64, and groups and other values as JSON. Absent and nil fields are
+// skipped. Keys and values are cut to [MaxLabelKeyBytes] and
+// [MaxLabelValueBytes], and an entry carries at most [MaxLabels] labels,
+// counting labels it already had.
+//
+// Example:
+//
+//	adapter := slogcpadapter.NewLogger(handler,
+//		slogcpadapter.WithLabelFields("grpc.service", "grpc.method", "grpc.code", "tenant"),
+//	)
+func WithLabelFields(fields ...string) LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.labelFields = append(cfg.labelFields, fields...)
+	}
+}
+
+// labelPromoter copies selected fields into the labels group.
+type labelPromoter struct {
+	fields []string
+	keys   []string
+}
+
+// newLabelPromoter compiles fields, returning nil when there are none.
+func newLabelPromoter(fields []string) *labelPromoter {
+	if len(fields) == 0 {
+		return nil
+	}
+	p := &labelPromoter{}
+	for _, f := range fields {
+		if key := sanitizeLabelKey(f); key != "" {
+			p.fields = append(p.fields, f)
+			p.keys = append(p.keys, key)
+		}
+	}
+	return p
+}
+
+// promote adds the selected fields found in attrs to the labels group,
+// merging with a labels group attrs already has.
+func (p *labelPromoter) promote(attrs []slog.Attr) []slog.Attr {
+	if p == nil {
+		return attrs
+	}
+	existing := -1
+	var labels []slog.Attr
+	for i, a := range attrs {
+		if a.Key == slogcp.LabelsGroup && a.Value.Kind() == slog.KindGroup {
+			existing = i
+			labels = append(labels, a.Value.Group()...)
+		}
+	}
+	seen := make(map[string]bool, len(labels)+len(p.keys))
+	for _, l := range labels {
+		seen[l.Key] = true
+	}
+	added := false
+	for i, field := range p.fields {
+		if len(labels) >= MaxLabels {
+			break
+		}
+		if seen[p.keys[i]] {
+			continue
+		}
+		v, ok := lookupField(attrs, field)
+		if !ok {
+			continue
+		}
+		s, ok := labelValue(v)
+		if !ok {
+			continue
+		}
+		seen[p.keys[i]] = true
+		labels = append(labels, slog.String(p.keys[i], truncateUTF8(s, MaxLabelValueBytes)))
+		added = true
+	}
+	if !added {
+		return attrs
+	}
+	group := slog.Attr{Key: slogcp.LabelsGroup, Value: slog.GroupValue(labels...)}
+	if existing >= 0 {
+		attrs[existing] = group
+		return attrs
+	}
+	return append(attrs, group)
+}
+
+// lookupField finds the value at path, descending into groups whose key is a
+// dotted prefix of it.
+func lookupField(attrs []slog.Attr, path string) (slog.Value, bool) {
+	for _, a := range attrs {
+		if a.Key == path {
+			return a.Value.Resolve(), true
+		}
+	}
+	for _, a := range attrs {
+		rest, ok := strings.CutPrefix(path, a.Key+".")
+		if !ok {
+			continue
+		}
+		if v := a.Value.Resolve(); v.Kind() == slog.KindGroup {
+			if found, ok := lookupField(v.Group(), rest); ok {
+				return found, true
+			}
+		}
+	}
+	return slog.Value{}, false
+}
+
+// labelValue stringifies v, reporting false for nil values.
+func labelValue(v slog.Value) (string, bool) {
+	switch v.Kind() {
+	case slog.KindString:
+		return v.String(), true
+	case slog.KindDuration:
+		return formatSeconds(v.Duration()), true
+	case slog.KindTime:
+		return v.Time().UTC().Format(time.RFC3339Nano), true
+	case slog.KindFloat64:
+		return strconv.FormatFloat(v.Float64(), 'g', -1, 64), true
+	case slog.KindBool, slog.KindInt64, slog.KindUint64:
+		return v.String(), true
+	case slog.KindGroup:
+		return jsonLabel(valueToAny(v))
+	default:
+		return anyLabel(v.Any())
+	}
+}
+
+// anyLabel stringifies an arbitrary value.
+func anyLabel(x any) (string, bool) {
+	switch y := x.(type) {
+	case nil:
+		return "", false
+	case []byte:
+		return base64.StdEncoding.EncodeToString(y), true
+	case error:
+		return y.Error(), true
+	default:
+		return jsonLabel(x)
+	}
+}
+
+// jsonLabel encodes x as JSON.
+func jsonLabel(x any) (string, bool) {
+	b, err := json.Marshal(x)
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+// sanitizeLabelKey replaces characters Cloud Logging label keys should not
+// contain and caps the key's length.
+func sanitizeLabelKey(key string) string {
+	key = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '.', r == '_', r == '/', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	return truncateUTF8(key, MaxLabelKeyBytes)
+}
+
+// truncateUTF8 cuts s to at most n bytes without splitting runes.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,106 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/pjscruggs/slogcp"
+)
+
+// labelsOf returns the labels group of r as a map.
+func labelsOf(t *testing.T, r slog.Record) map[string]any {
+	t.Helper()
+	labels, _ := valueToAny(attrValue(t, r, slogcp.LabelsGroup)).(map[string]any)
+	return labels
+}
+
+// TestLabelFieldsPromotion verifies selected fields become string labels.
+func TestLabelFieldsPromotion(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithLabelFields(
+		"grpc.service", "grpc.method", "grpc.code", "grpc.time_ms", "ok", "elapsed",
+		"grpc.start_time", "tenant.id", "tags", "missing", "nil", "weird key!",
+	))
+
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "finished call",
+		"grpc.service", "pkg.Svc",
+		"grpc.method", "Get",
+		"grpc.code", "OK",
+		"grpc.time_ms", 1.25,
+		"ok", true,
+		"elapsed", 1500*time.Millisecond,
+		"grpc.start_time", "2026-03-04T05:06:07Z",
+		slog.Group("tenant", slog.String("id", "t-1")),
+		"tags", []string{"a", "b"},
+		"nil", nil,
+		"weird key!", 7,
+	)
+	want := map[string]any{
+		"grpc.service":    "pkg.Svc",
+		"grpc.method":     "Get",
+		"grpc.code":       "OK",
+		"grpc.time_ms":    "1.25",
+		"ok":              "true",
+		"elapsed":         "1.5s",
+		"grpc.start_time": "2026-03-04T05:06:07Z",
+		"tenant.id":       "t-1",
+		"tags":            `["a","b"]`,
+		"weird_key_":      "7",
+	}
+	if got := labelsOf(t, rec.records[0]); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected labels %v, got %v", want, got)
+	}
+	if got := collectAttrs(rec.records[0])["grpc.method"]; got != "Get" {
+		t.Fatalf("expected promoted fields to stay in the payload, got %v", got)
+	}
+}
+
+// TestLabelFieldsLimits verifies existing labels are kept and the label count
+// and value length are capped.
+func TestLabelFieldsLimits(t *testing.T) {
+	rec := &recordingHandler{}
+	var fields []string
+	args := []any{slog.Group(slogcp.LabelsGroup, slog.String("existing", "yes"))}
+	for i := range MaxLabels + 5 {
+		key := "f" + strconv.Itoa(i)
+		fields = append(fields, key)
+		args = append(args, key, "v")
+	}
+	args = append(args, "big", strings.Repeat("é", MaxLabelValueBytes))
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithLabelFields("big"), WithLabelFields(fields...))
+
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "msg", args...)
+	labels := labelsOf(t, rec.records[0])
+	if len(labels) != MaxLabels {
+		t.Fatalf("expected %d labels, got %d", MaxLabels, len(labels))
+	}
+	if labels["existing"] != "yes" {
+		t.Fatalf("expected the existing label to be kept, got %v", labels["existing"])
+	}
+	if big := labels["big"].(string); len(big) != MaxLabelValueBytes {
+		t.Fatalf("expected the value to be cut to %d bytes, got %d", MaxLabelValueBytes, len(big))
+	}
+	if _, ok := labels["f"+strconv.Itoa(MaxLabels)]; ok {
+		t.Fatal("expected labels past the limit to be skipped")
+	}
+}
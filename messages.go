@@ -0,0 +1,236 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+)
+
+// MessagePlaceholder is the placeholder that renders the middleware's
+// original message, such as "finished call", in a message template.
+const MessagePlaceholder = "$message"
+
+// MessageRule renders the messages of matching call events from a template.
+//
+// A template is literal text with placeholders in braces. A placeholder names
+// an attribute of the entry, using dots to reach into groups, or is
+// [MessagePlaceholder]; "{{" and "}}" stand for literal braces. For example
+// "{grpc.code} /{grpc.service}/{grpc.method} {grpc.time_ms}ms" renders finish
+// entries as "OK /orders.v1.Orders/Get 12.5ms".
+type MessageRule struct {
+	// Method limits the rule to one method, such as "/orders.v1.Orders/Get",
+	// or to every method of a service, such as "/orders.v1.Orders/*". An
+	// empty Method matches every call.
+	Method string
+	// Events limits the rule to the listed events. An empty list matches
+	// every call event.
+	Events []grpc_logging.LoggableEvent
+	// Template is the message template.
+	Template string
+}
+
+// MessageTemplates holds compiled [MessageRule] values. It is installed with
+// [WithMessageTemplates].
+type MessageTemplates struct {
+	rules []messageRule
+}
+
+type messageRule struct {
+	method   string
+	service  bool
+	events   []grpc_logging.LoggableEvent
+	template messageTemplate
+}
+
+// NewMessageTemplates compiles rules. For each entry the first matching rule
+// wins, so list method-specific rules before general ones. An unterminated or
+// empty placeholder is an error.
+//
+// Example:
+//
+//	templates, err := slogcpadapter.NewMessageTemplates(
+//		slogcpadapter.MessageRule{
+//			Method:   "/orders.v1.Orders/*",
+//			Events:   []grpc_logging.LoggableEvent{grpc_logging.FinishCall},
+//			Template: "{grpc.code} /{grpc.service}/{grpc.method} {grpc.time_ms}ms order={grpc.request.fields.order_id}",
+//		},
+//		slogcpadapter.MessageRule{
+//			Events:   []grpc_logging.LoggableEvent{grpc_logging.FinishCall},
+//			Template: "{grpc.code} /{grpc.service}/{grpc.method} {grpc.time_ms}ms",
+//		},
+//	)
+func NewMessageTemplates(rules ...MessageRule) (*MessageTemplates, error) {
+	t := &MessageTemplates{rules: make([]messageRule, 0, len(rules))}
+	for _, r := range rules {
+		tmpl, err := compileMessageTemplate(r.Template)
+		if err != nil {
+			return nil, err
+		}
+		method, service := strings.CutSuffix(r.Method, "*")
+		t.rules = append(t.rules, messageRule{
+			method:   method,
+			service:  service,
+			events:   slices.Clone(r.Events),
+			template: tmpl,
+		})
+	}
+	return t, nil
+}
+
+// WithMessageTemplates makes [NewLogger] render the messages of call events
+// from templates. Entries that match no rule, and entries whose template
+// names an attribute the entry does not have, keep the original message.
+// Messages are rendered after the adapter's own annotations, so templates
+// can name attributes such as [OperationKey] or [InsertIDKey], and before
+// [Transformer] functions run. A nil templates is ignored.
+func WithMessageTemplates(templates *MessageTemplates) LoggerOption {
+	return func(cfg *loggerConfig) {
+		if templates != nil {
+			cfg.messages = templates
+		}
+	}
+}
+
+// messageTemplate is a compiled template: literal text alternating with
+// placeholders.
+type messageTemplate []templatePart
+
+type templatePart struct {
+	text  string
+	field bool
+}
+
+// compileMessageTemplate parses s into literal text and placeholders.
+func compileMessageTemplate(s string) (messageTemplate, error) {
+	var (
+		t       messageTemplate
+		literal strings.Builder
+	)
+	for i := 0; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "{{"), strings.HasPrefix(s[i:], "}}"):
+			literal.WriteByte(s[i])
+			i++
+		case s[i] == '{':
+			end := strings.IndexByte(s[i+1:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("slogcpadapter: message template %q: unterminated placeholder", s)
+			}
+			name := strings.TrimSpace(s[i+1 : i+1+end])
+			if name == "" {
+				return nil, fmt.Errorf("slogcpadapter: message template %q: empty placeholder", s)
+			}
+			if literal.Len() > 0 {
+				t = append(t, templatePart{text: literal.String()})
+				literal.Reset()
+			}
+			t = append(t, templatePart{text: name, field: true})
+			i += end + 1
+		default:
+			literal.WriteByte(s[i])
+		}
+	}
+	if literal.Len() > 0 {
+		t = append(t, templatePart{text: literal.String()})
+	}
+	return t, nil
+}
+
+// execute renders t for an entry with msg and attrs, reporting false when a
+// placeholder names a missing attribute.
+func (t messageTemplate) execute(msg string, attrs []slog.Attr) (string, bool) {
+	var b strings.Builder
+	for _, p := range t {
+		switch {
+		case !p.field:
+			b.WriteString(p.text)
+		case p.text == MessagePlaceholder:
+			b.WriteString(msg)
+		default:
+			v, ok := lookupField(attrs, p.text)
+			if !ok {
+				return "", false
+			}
+			s, ok := labelValue(v)
+			if !ok {
+				return "", false
+			}
+			b.WriteString(s)
+		}
+	}
+	return b.String(), true
+}
+
+// render returns the message of an entry logged with msg and attrs for call,
+// which may be nil. It returns msg unchanged when t is nil, msg is not a call
+// event, no rule matches or the matching template fails.
+func (t *MessageTemplates) render(call *callState, msg string, attrs []slog.Attr) string {
+	if t == nil {
+		return msg
+	}
+	event, known := classifyEvent(msg)
+	if !known {
+		return msg
+	}
+	method := entryMethod(call, attrs)
+	for _, r := range t.rules {
+		if !r.matches(method, event) {
+			continue
+		}
+		if out, ok := r.template.execute(msg, attrs); ok {
+			return out
+		}
+		return msg
+	}
+	return msg
+}
+
+// matches reports whether r applies to event on method.
+func (r messageRule) matches(method string, event grpc_logging.LoggableEvent) bool {
+	if len(r.events) > 0 && !slices.Contains(r.events, event) {
+		return false
+	}
+	if r.service {
+		return strings.HasPrefix(method, r.method)
+	}
+	return r.method == "" || r.method == method
+}
+
+// entryMethod returns the full method of the call an entry belongs to, from
+// call when the Logger's interceptors track it and otherwise from the
+// middleware's grpc.service and grpc.method fields.
+func entryMethod(call *callState, attrs []slog.Attr) string {
+	if call != nil {
+		return call.method
+	}
+	var service, method string
+	for _, a := range attrs {
+		switch a.Key {
+		case "grpc.service":
+			service = a.Value.String()
+		case "grpc.method":
+			method = a.Value.String()
+		}
+	}
+	if service == "" || method == "" {
+		return ""
+	}
+	return "/" + service + "/" + method
+}
@@ -0,0 +1,103 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+)
+
+// TestMessageTemplatesRenderByEventAndMethod verifies rules are chosen by event
+// and method, first match first, and that other entries keep their message.
+func TestMessageTemplatesRenderByEventAndMethod(t *testing.T) {
+	templates, err := NewMessageTemplates(
+		MessageRule{
+			Method:   "/orders.v1.Orders/*",
+			Events:   []grpc_logging.LoggableEvent{grpc_logging.FinishCall},
+			Template: "{grpc.code} /{grpc.service}/{grpc.method} {grpc.time_ms}ms order={req.id}",
+		},
+		MessageRule{
+			Events:   []grpc_logging.LoggableEvent{grpc_logging.FinishCall},
+			Template: "{grpc.code} /{grpc.service}/{grpc.method} {grpc.time_ms}ms",
+		},
+		MessageRule{
+			Method:   "/pkg.Svc/Get",
+			Template: "{{{$message}}} {grpc.method}",
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewMessageTemplates: %v", err)
+	}
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithMessageTemplates(templates))
+
+	finish := func(service, method string, extra ...any) []any {
+		return append([]any{"grpc.service", service, "grpc.method", method, "grpc.code", "OK", "grpc.time_ms", "12"}, extra...)
+	}
+	ctx := context.Background()
+	logger.Log(ctx, grpc_logging.LevelInfo, "finished call", finish("orders.v1.Orders", "Get", slog.Group("req", slog.Int("id", 7)))...)
+	logger.Log(ctx, grpc_logging.LevelInfo, "finished call", finish("orders.v1.Orders", "List")...)
+	logger.Log(ctx, grpc_logging.LevelInfo, "finished call", finish("pkg.Svc", "Get")...)
+	logger.Log(ctx, grpc_logging.LevelInfo, "started call", "grpc.service", "pkg.Svc", "grpc.method", "Get")
+	logger.Log(ctx, grpc_logging.LevelInfo, "started call", "grpc.service", "pkg.Svc", "grpc.method", "List")
+	logger.Log(ctx, grpc_logging.LevelInfo, "handler note", "grpc.service", "pkg.Svc", "grpc.method", "Get")
+
+	want := []string{
+		"OK /orders.v1.Orders/Get 12ms order=7",
+		"finished call",
+		"OK /pkg.Svc/Get 12ms",
+		"{started call} Get",
+		"started call",
+		"handler note",
+	}
+	if len(rec.records) != len(want) {
+		t.Fatalf("expected %d records, got %d", len(want), len(rec.records))
+	}
+	for i, r := range rec.records {
+		if r.Message != want[i] {
+			t.Errorf("record %d: expected message %q, got %q", i, want[i], r.Message)
+		}
+	}
+}
+
+// TestMessageTemplatesUseCallMethod verifies calls tracked by the Logger's
+// interceptors are matched by their full method.
+func TestMessageTemplatesUseCallMethod(t *testing.T) {
+	templates, err := NewMessageTemplates(MessageRule{Method: "/pkg.Svc/Get", Template: "{$message} {grpc.component}"})
+	if err != nil {
+		t.Fatalf("NewMessageTemplates: %v", err)
+	}
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithMessageTemplates(templates))
+	ctx, call := logger.beginCall(context.Background(), "/pkg.Svc/Get", false)
+	defer logger.endCall(call)
+
+	logger.Log(ctx, grpc_logging.LevelInfo, "started call", "grpc.component", "server")
+	if len(rec.records) != 1 || rec.records[0].Message != "started call server" {
+		t.Fatalf("expected rendered message, got %+v", rec.records)
+	}
+}
+
+// TestNewMessageTemplatesRejectsMalformedTemplates verifies compile errors are reported.
+func TestNewMessageTemplatesRejectsMalformedTemplates(t *testing.T) {
+	for _, tmpl := range []string{"{grpc.code", "code={}", "{ }"} {
+		if _, err := NewMessageTemplates(MessageRule{Template: tmpl}); err == nil {
+			t.Errorf("expected an error for %q", tmpl)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"encoding/base64"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Attribute keys for logged metadata.
+const (
+	RequestMetadataKey  = "grpc.request.metadata"
+	ResponseHeaderKey   = "grpc.response.header"
+	ResponseTrailerKey  = "grpc.response.trailer"
+	grpcTimeoutMetadata = "grpc-timeout"
+)
+
+// DefaultDeniedMetadata lists the metadata keys that are never logged unless
+// named explicitly in [WithMetadataAllowlist].
+var DefaultDeniedMetadata = []string{"authorization", "cookie", "set-cookie", "proxy-authorization"}
+
+// WithMetadataAllowlist makes the Logger's interceptors log the named metadata
+// keys under [RequestMetadataKey]: incoming metadata on servers and outgoing
+// metadata on clients. Keys are matched case-insensitively and the option may
+// be given several times. Keys ending in "-bin" are base64 encoded, and keys
+// with several values are logged as arrays.
+//
+// grpc-timeout is consumed by the transport before it reaches metadata; when
+// allowlisted it is reported as the time left until the call's deadline.
+//
+// Example:
+//
+//	adapter := slogcpadapter.NewLogger(handler,
+//		slogcpadapter.WithMetadataAllowlist("user-agent", "x-goog-api-client", "x-tenant", "grpc-timeout"),
+//	)
+func WithMetadataAllowlist(keys ...string) LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.metadata.allow = appendKeys(cfg.metadata.allow, keys)
+	}
+}
+
+// WithMetadataDenylist keeps the named metadata keys out of logs, even when
+// allowlisted. With a denylist and no allowlist, every other key is logged.
+// [DefaultDeniedMetadata] is always denied unless explicitly allowlisted.
+func WithMetadataDenylist(keys ...string) LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.metadata.deny = appendKeys(cfg.metadata.deny, keys)
+	}
+}
+
+// WithResponseMetadata makes the Logger's interceptors also log response
+// headers and trailers under [ResponseHeaderKey] and [ResponseTrailerKey],
+// filtered by the same allowlist and denylist. With neither list set, every
+// key except [DefaultDeniedMetadata] is logged.
+func WithResponseMetadata() LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.metadata.responses = true
+	}
+}
+
+// metadataConfig collects the metadata options before NewLogger compiles them.
+type metadataConfig struct {
+	allow     []string
+	deny      []string
+	responses bool
+}
+
+func appendKeys(dst, keys []string) []string {
+	for _, k := range keys {
+		dst = append(dst, strings.ToLower(k))
+	}
+	return dst
+}
+
+// metadataFilter decides which metadata keys are logged.
+type metadataFilter struct {
+	allow     map[string]bool
+	deny      map[string]bool
+	requests  bool
+	responses bool
+	ips       *ipAnonymizer
+}
+
+// newMetadataFilter compiles cfg, returning nil when metadata logging is off.
+func newMetadataFilter(cfg metadataConfig, ips *ipAnonymizer) *metadataFilter {
+	requests := len(cfg.allow) > 0 || len(cfg.deny) > 0
+	if !requests && !cfg.responses {
+		return nil
+	}
+	f := &metadataFilter{allow: make(map[string]bool), deny: make(map[string]bool), requests: requests, responses: cfg.responses, ips: ips}
+	for _, k := range cfg.allow {
+		f.allow[k] = true
+	}
+	for _, k := range DefaultDeniedMetadata {
+		if !f.allow[k] {
+			f.deny[k] = true
+		}
+	}
+	for _, k := range cfg.deny {
+		f.deny[k] = true
+	}
+	return f
+}
+
+// permits reports whether key may be logged.
+func (f *metadataFilter) permits(key string) bool {
+	if f.deny[key] {
+		return false
+	}
+	return len(f.allow) == 0 || f.allow[key]
+}
+
+// attrs renders the permitted keys of md, sorted by key.
+func (f *metadataFilter) attrs(md metadata.MD) []slog.Attr {
+	var out []slog.Attr
+	for key, values := range md {
+		key = strings.ToLower(key)
+		if len(values) == 0 || !f.permits(key) {
+			continue
+		}
+		out = append(out, slog.Attr{Key: key, Value: metadataValue(key, f.ips.metadataValues(key, values))})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+// metadataValue renders values as a string, or an array when there are several.
+func metadataValue(key string, values []string) slog.Value {
+	encoded := values
+	if strings.HasSuffix(key, "-bin") {
+		encoded = make([]string, len(values))
+		for i, v := range values {
+			encoded[i] = base64.StdEncoding.EncodeToString([]byte(v))
+		}
+	}
+	if len(encoded) == 1 {
+		return slog.StringValue(encoded[0])
+	}
+	return slog.AnyValue(append([]string(nil), encoded...))
+}
+
+// callMetadata holds the metadata captured for one call.
+type callMetadata struct {
+	mu      sync.Mutex
+	request []slog.Attr
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+// beginMetadata captures request metadata for call and, on servers, installs a
+// transport stream that records the response header and trailer.
+func (l *Logger) beginMetadata(ctx context.Context, call *callState) context.Context {
+	f := l.metadataFilter
+	if f == nil {
+		return ctx
+	}
+	call.metadata = &callMetadata{}
+	if f.requests {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if call.isClient {
+			md, _ = metadata.FromOutgoingContext(ctx)
+		}
+		call.metadata.request = f.attrs(md)
+		if !call.deadline.IsZero() && f.allow[grpcTimeoutMetadata] && f.permits(grpcTimeoutMetadata) {
+			timeout := call.deadline.Sub(call.start).Round(time.Millisecond)
+			call.metadata.request = append(call.metadata.request, slog.String(grpcTimeoutMetadata, timeout.String()))
+		}
+	}
+	if f.responses && !call.isClient {
+		if sts := grpc.ServerTransportStreamFromContext(ctx); sts != nil {
+			ctx = grpc.NewContextWithServerTransportStream(ctx, &recordingTransportStream{ServerTransportStream: sts, md: call.metadata})
+		}
+	}
+	return ctx
+}
+
+// addHeader merges md into the captured response header.
+func (m *callMetadata) addHeader(md metadata.MD) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.header = metadata.Join(m.header, md)
+}
+
+// addTrailer merges md into the captured response trailer.
+func (m *callMetadata) addTrailer(md metadata.MD) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.trailer = metadata.Join(m.trailer, md)
+}
+
+// metadataAttrs returns the metadata groups captured for call so far.
+func (l *Logger) metadataAttrs(call *callState) []slog.Attr {
+	m := call.metadata
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []slog.Attr
+	for _, g := range []struct {
+		key   string
+		attrs []slog.Attr
+	}{
+		{RequestMetadataKey, m.request},
+		{ResponseHeaderKey, l.metadataFilter.attrs(m.header)},
+		{ResponseTrailerKey, l.metadataFilter.attrs(m.trailer)},
+	} {
+		if len(g.attrs) > 0 {
+			out = append(out, slog.Attr{Key: g.key, Value: slog.GroupValue(g.attrs...)})
+		}
+	}
+	return out
+}
+
+// recordingTransportStream records the header and trailer a server handler sets.
+type recordingTransportStream struct {
+	grpc.ServerTransportStream
+	md *callMetadata
+}
+
+// SetHeader records md and forwards it.
+func (s *recordingTransportStream) SetHeader(md metadata.MD) error {
+	s.md.addHeader(md)
+	return s.ServerTransportStream.SetHeader(md)
+}
+
+// SendHeader records md and forwards it.
+func (s *recordingTransportStream) SendHeader(md metadata.MD) error {
+	s.md.addHeader(md)
+	return s.ServerTransportStream.SendHeader(md)
+}
+
+// SetTrailer records md and forwards it.
+func (s *recordingTransportStream) SetTrailer(md metadata.MD) error {
+	s.md.addTrailer(md)
+	return s.ServerTransportStream.SetTrailer(md)
+}
+
+// metadataServerStream records the header and trailer a stream handler sets
+// directly on its grpc.ServerStream.
+type metadataServerStream struct {
+	grpc.ServerStream
+	md *callMetadata
+}
+
+// SetHeader records md and forwards it.
+func (s *metadataServerStream) SetHeader(md metadata.MD) error {
+	s.md.addHeader(md)
+	return s.ServerStream.SetHeader(md)
+}
+
+// SendHeader records md and forwards it.
+func (s *metadataServerStream) SendHeader(md metadata.MD) error {
+	s.md.addHeader(md)
+	return s.ServerStream.SendHeader(md)
+}
+
+// SetTrailer records md and forwards it.
+func (s *metadataServerStream) SetTrailer(md metadata.MD) {
+	s.md.addTrailer(md)
+	s.ServerStream.SetTrailer(md)
+}
+
+// metadataServerStreamFor wraps ss to record response metadata when enabled.
+func (l *Logger) metadataServerStreamFor(call *callState, ss grpc.ServerStream) grpc.ServerStream {
+	if call.metadata == nil || !l.metadataFilter.responses {
+		return ss
+	}
+	return &metadataServerStream{ServerStream: ss, md: call.metadata}
+}
+
+// metadataInvoker wraps invoker so the response header and trailer are
+// captured before the logging middleware writes its finish entry.
+func (l *Logger) metadataInvoker(call *callState, invoker grpc.UnaryInvoker) grpc.UnaryInvoker {
+	if call.metadata == nil || !l.metadataFilter.responses {
+		return invoker
+	}
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		var header, trailer metadata.MD
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header), grpc.Trailer(&trailer))...)
+		call.metadata.addHeader(header)
+		call.metadata.addTrailer(trailer)
+		return err
+	}
+}
+
+// metadataStreamer wraps streamer so the returned stream records the response
+// header and trailer once the stream ends.
+func (l *Logger) metadataStreamer(call *callState, streamer grpc.Streamer) grpc.Streamer {
+	if call.metadata == nil || !l.metadataFilter.responses {
+		return streamer
+	}
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return cs, err
+		}
+		return &metadataClientStream{ClientStream: cs, md: call.metadata}, nil
+	}
+}
+
+// metadataClientStream records the response header and trailer of a client stream.
+type metadataClientStream struct {
+	grpc.ClientStream
+	md   *callMetadata
+	once sync.Once
+}
+
+// RecvMsg records the header and trailer when the stream ends.
+func (s *metadataClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.once.Do(func() {
+			if header, herr := s.ClientStream.Header(); herr == nil {
+				s.md.addHeader(header)
+			}
+			s.md.addTrailer(s.ClientStream.Trailer())
+		})
+	}
+	return err
+}
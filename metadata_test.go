@@ -0,0 +1,165 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeTransportStream is a grpc.ServerTransportStream that accepts all metadata.
+type fakeTransportStream struct{}
+
+func (fakeTransportStream) Method() string               { return "/pkg.Svc/Get" }
+func (fakeTransportStream) SetHeader(metadata.MD) error  { return nil }
+func (fakeTransportStream) SendHeader(metadata.MD) error { return nil }
+func (fakeTransportStream) SetTrailer(metadata.MD) error { return nil }
+
+// TestMetadataServerRequestAndResponse verifies incoming metadata and response metadata are logged.
+func TestMetadataServerRequestAndResponse(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)),
+		WithMetadataAllowlist("User-Agent", "x-tenant", "x-trace-bin", "authorization", "grpc-timeout", "x-served-by", "x-cost"),
+		WithMetadataDenylist("authorization"),
+		WithResponseMetadata(),
+	)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+		"user-agent":    {"grpc-go/1.0"},
+		"x-tenant":      {"a", "b"},
+		"x-trace-bin":   {"\x01\x02"},
+		"x-other":       {"skip"},
+		"authorization": {"Bearer secret"},
+	})
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	ctx = grpc.NewContextWithServerTransportStream(ctx, fakeTransportStream{})
+
+	interceptor := logger.UnaryServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.FinishCall))
+	info := &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Get"}
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, _ any) (any, error) {
+		if err := grpc.SetHeader(ctx, metadata.Pairs("x-served-by", "node-1", "set-cookie", "s=1")); err != nil {
+			return nil, err
+		}
+		return nil, grpc.SetTrailer(ctx, metadata.Pairs("x-cost", "7"))
+	})
+	if err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+
+	r := rec.records[0]
+	request, _ := valueToAny(attrValue(t, r, RequestMetadataKey)).(map[string]any)
+	timeout, _ := request["grpc-timeout"].(string)
+	delete(request, "grpc-timeout")
+	want := map[string]any{"user-agent": "grpc-go/1.0", "x-tenant": []string{"a", "b"}, "x-trace-bin": "AQI="}
+	if !reflect.DeepEqual(request, want) {
+		t.Fatalf("expected request metadata %v, got %v", want, request)
+	}
+	if !strings.HasSuffix(timeout, "s") {
+		t.Fatalf("expected grpc-timeout from the deadline, got %q", timeout)
+	}
+	if got := valueToAny(attrValue(t, r, ResponseHeaderKey)); !reflect.DeepEqual(got, map[string]any{"x-served-by": "node-1"}) {
+		t.Fatalf("expected response header without set-cookie, got %v", got)
+	}
+	if got := valueToAny(attrValue(t, r, ResponseTrailerKey)); !reflect.DeepEqual(got, map[string]any{"x-cost": "7"}) {
+		t.Fatalf("expected response trailer, got %v", got)
+	}
+}
+
+// TestMetadataDefaultDenylist verifies a denylist alone logs everything but sensitive keys.
+func TestMetadataDefaultDenylist(t *testing.T) {
+	f := newMetadataFilter(metadataConfig{deny: []string{"x-internal"}}, nil)
+	got := valueToAny(slog.GroupValue(f.attrs(metadata.MD{
+		"authorization": {"Bearer secret"},
+		"cookie":        {"c=1"},
+		"x-internal":    {"1"},
+		"x-tenant":      {"t"},
+	})...))
+	if want := map[string]any{"x-tenant": "t"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if newMetadataFilter(metadataConfig{}, nil) != nil {
+		t.Fatalf("expected metadata logging to be off by default")
+	}
+	if !newMetadataFilter(metadataConfig{allow: []string{"authorization"}}, nil).permits("authorization") {
+		t.Fatalf("expected an explicit allowlist entry to override the default denylist")
+	}
+}
+
+// TestMetadataRedacted verifies redaction rules reach logged metadata.
+func TestMetadataRedacted(t *testing.T) {
+	redactor, err := NewRedactor(nil,
+		RedactionRule{Key: "x-tenant"},
+		RedactionRule{Name: "email", ValuePattern: EmailPattern},
+	)
+	if err != nil {
+		t.Fatalf("NewRedactor: %v", err)
+	}
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithRedactor(redactor), WithMetadataAllowlist("x-tenant", "x-user"))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", "acme", "x-user", "bob@example.com"))
+
+	interceptor := logger.UnaryServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.FinishCall))
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Get"}, func(context.Context, any) (any, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+
+	got := valueToAny(attrValue(t, rec.records[0], RequestMetadataKey))
+	if want := map[string]any{"x-tenant": RedactedValue, "x-user": RedactedValue}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if counts := redactor.Counts(); counts["x-tenant"] != 1 || counts["email"] != 1 {
+		t.Fatalf("expected one redaction per rule, got %v", counts)
+	}
+}
+
+// TestMetadataClientOutgoingAndResponse verifies clients log outgoing metadata and the response header.
+func TestMetadataClientOutgoingAndResponse(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithMetadataAllowlist("x-tenant", "x-served-by"), WithResponseMetadata())
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-tenant", "acme", "cookie", "c=1")
+	interceptor := logger.UnaryClientInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.FinishCall))
+	invoker := func(_ context.Context, _ string, _, _ any, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+		for _, opt := range opts {
+			if h, ok := opt.(grpc.HeaderCallOption); ok {
+				*h.HeaderAddr = metadata.Pairs("x-served-by", "node-2")
+			}
+		}
+		return nil
+	}
+	if err := interceptor(ctx, "/pkg.Svc/Get", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+
+	r := rec.records[0]
+	if got := valueToAny(attrValue(t, r, RequestMetadataKey)); !reflect.DeepEqual(got, map[string]any{"x-tenant": "acme"}) {
+		t.Fatalf("expected outgoing metadata, got %v", got)
+	}
+	if got := valueToAny(attrValue(t, r, ResponseHeaderKey)); !reflect.DeepEqual(got, map[string]any{"x-served-by": "node-2"}) {
+		t.Fatalf("expected client response header, got %v", got)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"log/slog"
+	"strconv"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+)
+
+// OperationKey is the group Cloud Logging reads into a LogEntry's operation,
+// which the Logs Explorer uses to show related entries as one operation.
+const OperationKey = "logging.googleapis.com/operation"
+
+// WithOperations makes the Logger's interceptors tie every entry of a call
+// together under [OperationKey]: "id" is unique to the call, "producer" is
+// its full method, and "first" and "last" are set on the started call and
+// finished call entries. A summary entry from [WithCallSummaries] that holds
+// the started call entry sets both.
+func WithOperations() LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.operations = true
+	}
+}
+
+// operationID returns the operation ID of call.
+func (call *callState) operationID() string {
+	return processPrefix + "-" + strconv.FormatUint(call.id, 10)
+}
+
+// operationAttr describes call as an operation for an entry logged for event.
+func operationAttr(call *callState, event grpc_logging.LoggableEvent, known bool) slog.Attr {
+	attrs := make([]slog.Attr, 0, 4)
+	attrs = append(attrs,
+		slog.String("id", call.operationID()),
+		slog.String("producer", call.method),
+	)
+	switch {
+	case known && event == grpc_logging.StartCall:
+		attrs = append(attrs, slog.Bool("first", true))
+	case known && event == grpc_logging.FinishCall:
+		if call.summary.merged() {
+			attrs = append(attrs, slog.Bool("first", true))
+		}
+		attrs = append(attrs, slog.Bool("last", true))
+	}
+	return slog.Attr{Key: OperationKey, Value: slog.GroupValue(attrs...)}
+}
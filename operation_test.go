@@ -0,0 +1,83 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"testing"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// TestOperationsGroupCallEntries verifies start and finish entries share an
+// operation that is distinct from other calls.
+func TestOperationsGroupCallEntries(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithOperations())
+	interceptor := logger.UnaryServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.StartCall, grpc_logging.FinishCall))
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{}})
+	info := &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Get"}
+	handler := func(ctx context.Context, _ any) (any, error) {
+		logger.Log(ctx, grpc_logging.LevelInfo, "handler note")
+		return nil, nil
+	}
+
+	for range 2 {
+		if _, err := interceptor(ctx, nil, info, handler); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(rec.records) != 6 {
+		t.Fatalf("expected 6 records, got %d", len(rec.records))
+	}
+
+	ops := make([]map[string]any, len(rec.records))
+	for i, r := range rec.records {
+		ops[i] = valueToAny(attrValue(t, r, OperationKey)).(map[string]any)
+		if ops[i]["producer"] != "/pkg.Svc/Get" {
+			t.Fatalf("unexpected producer in %v", ops[i])
+		}
+	}
+	first, note, last := ops[0], ops[1], ops[2]
+	if first["first"] != true || first["last"] != nil {
+		t.Fatalf("expected first=true on the start entry, got %v", first)
+	}
+	if note["first"] != nil || note["last"] != nil {
+		t.Fatalf("expected neither flag on other entries, got %v", note)
+	}
+	if last["last"] != true || last["first"] != nil {
+		t.Fatalf("expected last=true on the finish entry, got %v", last)
+	}
+	if first["id"] != note["id"] || first["id"] != last["id"] {
+		t.Fatalf("expected one operation ID per call, got %v %v %v", first["id"], note["id"], last["id"])
+	}
+	if ops[3]["id"] == first["id"] {
+		t.Fatalf("expected a new operation ID for the second call, got %v", ops[3]["id"])
+	}
+}
+
+// TestOperationsDisabledByDefault verifies no operation is logged without the option.
+func TestOperationsDisabledByDefault(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)))
+	runWithPeer(t, logger, rec, &peer.Peer{Addr: &net.TCPAddr{}})
+	if _, ok := collectAttrs(rec.records[0])[OperationKey]; ok {
+		t.Fatal("expected no operation without WithOperations")
+	}
+}
@@ -0,0 +1,339 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Attribute keys for fields extracted by a [PayloadFieldSelector].
+const (
+	RequestFieldsKey  = "grpc.request.fields"
+	ResponseFieldsKey = "grpc.response.fields"
+)
+
+// PayloadFields lists the proto field paths to extract from one method's
+// messages, in FieldMask syntax: snake_case field names joined by dots, such
+// as "customer_id" or "order.id".
+type PayloadFields struct {
+	Request  []string
+	Response []string
+}
+
+// PayloadFieldSelector extracts selected fields from request and response
+// messages into the [RequestFieldsKey] and [ResponseFieldsKey] groups, without
+// logging whole payloads. It is installed with [WithPayloadFields].
+type PayloadFieldSelector struct {
+	methods map[string]*methodFields
+}
+
+type methodFields struct {
+	request  []fieldAccessor
+	response []fieldAccessor
+}
+
+// fieldAccessor is a resolved field path: the descriptors to follow from the
+// top-level message down to the selected field.
+type fieldAccessor struct {
+	path string
+	fds  []protoreflect.FieldDescriptor
+}
+
+// NewPayloadFieldSelector resolves the paths in methods, keyed by full method
+// name such as "/shop.v1.Orders/Create", against the descriptors in
+// [protoregistry.GlobalFiles]. Unknown methods and paths that do not exist on
+// the method's input or output type are reported as errors, so call it at
+// startup after the generated packages have been imported.
+//
+// Example:
+//
+//	selector, err := slogcpadapter.NewPayloadFieldSelector(map[string]slogcpadapter.PayloadFields{
+//		"/shop.v1.Orders/Create": {Request: []string{"customer_id", "order.id"}, Response: []string{"order.status"}},
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	adapter := slogcpadapter.NewLogger(handler, slogcpadapter.WithPayloadFields(selector))
+func NewPayloadFieldSelector(methods map[string]PayloadFields) (*PayloadFieldSelector, error) {
+	s := &PayloadFieldSelector{methods: make(map[string]*methodFields, len(methods))}
+	for fullMethod, fields := range methods {
+		md, err := findMethod(fullMethod)
+		if err != nil {
+			return nil, err
+		}
+		request, err := resolvePaths(md.Input(), fields.Request)
+		if err != nil {
+			return nil, fmt.Errorf("slogcpadapter: %s request: %w", fullMethod, err)
+		}
+		response, err := resolvePaths(md.Output(), fields.Response)
+		if err != nil {
+			return nil, fmt.Errorf("slogcpadapter: %s response: %w", fullMethod, err)
+		}
+		s.methods[fullMethod] = &methodFields{request: request, response: response}
+	}
+	return s, nil
+}
+
+// WithPayloadFields makes [NewLogger] add the fields chosen by s to the entries
+// of the Logger's own interceptors. A nil selector is ignored.
+func WithPayloadFields(s *PayloadFieldSelector) LoggerOption {
+	return func(cfg *loggerConfig) {
+		if s != nil {
+			cfg.payloadFields = s
+		}
+	}
+}
+
+// findMethod looks up "/pkg.Service/Method" in the global registry.
+func findMethod(fullMethod string) (protoreflect.MethodDescriptor, error) {
+	service, method := splitFullMethod(fullMethod)
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("slogcpadapter: method %s: %w", fullMethod, err)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("slogcpadapter: method %s: %s is not a service", fullMethod, service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, fmt.Errorf("slogcpadapter: method %s: not found in %s", fullMethod, service)
+	}
+	return md, nil
+}
+
+// resolvePaths resolves each path against md. Every segment but the last must
+// name a singular message field.
+func resolvePaths(md protoreflect.MessageDescriptor, paths []string) ([]fieldAccessor, error) {
+	out := make([]fieldAccessor, 0, len(paths))
+	for _, path := range paths {
+		acc := fieldAccessor{path: path}
+		current := md
+		for i, name := range strings.Split(path, ".") {
+			if current == nil {
+				return nil, fmt.Errorf("path %q: %s is not a message", path, acc.fds[i-1].Name())
+			}
+			fd := current.Fields().ByName(protoreflect.Name(name))
+			if fd == nil {
+				return nil, fmt.Errorf("path %q: %s has no field %q", path, current.FullName(), name)
+			}
+			acc.fds = append(acc.fds, fd)
+			current = nil
+			if fd.Message() != nil && !fd.IsList() && !fd.IsMap() {
+				current = fd.Message()
+			}
+		}
+		out = append(out, acc)
+	}
+	return out, nil
+}
+
+// forMethod returns the accessors configured for fullMethod, or nil.
+func (s *PayloadFieldSelector) forMethod(fullMethod string) *methodFields {
+	if s == nil {
+		return nil
+	}
+	return s.methods[fullMethod]
+}
+
+// callFields holds the fields captured for one call. The first request and the
+// first response seen are kept, so streams report their opening messages.
+type callFields struct {
+	mu       sync.Mutex
+	selected *methodFields
+	request  []slog.Attr
+	response []slog.Attr
+}
+
+// attrs returns the groups captured so far.
+func (f *callFields) attrs() []slog.Attr {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []slog.Attr
+	if len(f.request) > 0 {
+		out = append(out, slog.Attr{Key: RequestFieldsKey, Value: slog.GroupValue(f.request...)})
+	}
+	if len(f.response) > 0 {
+		out = append(out, slog.Attr{Key: ResponseFieldsKey, Value: slog.GroupValue(f.response...)})
+	}
+	return out
+}
+
+// captureRequest records the selected fields of msg if no request has been captured yet.
+func (l *Logger) captureRequest(call *callState, msg any) {
+	if f := call.fields; f != nil && len(f.selected.request) > 0 {
+		l.capture(f, &f.request, f.selected.request, msg)
+	}
+}
+
+// captureResponse records the selected fields of msg if no response has been captured yet.
+func (l *Logger) captureResponse(call *callState, msg any) {
+	if f := call.fields; f != nil && len(f.selected.response) > 0 {
+		l.capture(f, &f.response, f.selected.response, msg)
+	}
+}
+
+// capture stores the redacted fields of msg in dst unless dst is already set.
+func (l *Logger) capture(f *callFields, dst *[]slog.Attr, accessors []fieldAccessor, msg any) {
+	pm, ok := msg.(proto.Message)
+	if !ok || pm == nil {
+		return
+	}
+	f.mu.Lock()
+	captured := *dst != nil
+	f.mu.Unlock()
+	if captured {
+		return
+	}
+	if l.redactor != nil {
+		pm = l.redactor.redactMessage(pm)
+	}
+	attrs := l.extractFields(pm.ProtoReflect(), accessors)
+	f.mu.Lock()
+	if *dst == nil {
+		*dst = attrs
+	}
+	f.mu.Unlock()
+}
+
+// extractFields reads each accessor's field from m. Fields that are unset,
+// or whose parent message is unset, are left out; sensitive fields follow the
+// configured [SensitiveFieldMode].
+func (l *Logger) extractFields(m protoreflect.Message, accessors []fieldAccessor) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(accessors))
+	for _, acc := range accessors {
+		if v, ok := l.extractField(m, acc); ok {
+			attrs = append(attrs, slog.Attr{Key: acc.path, Value: v})
+		}
+	}
+	return attrs
+}
+
+// extractField reads acc's field from m, reporting false when it is left out.
+func (l *Logger) extractField(m protoreflect.Message, acc fieldAccessor) (slog.Value, bool) {
+	last := len(acc.fds) - 1
+	for _, fd := range acc.fds[:last] {
+		if !m.Has(fd) {
+			return slog.Value{}, false
+		}
+		m = m.Get(fd).Message()
+	}
+	fd := acc.fds[last]
+	if !m.Has(fd) {
+		return slog.Value{}, false
+	}
+	if l.protos.isSensitive(fd) {
+		return slog.StringValue(RedactedValue), l.protos.mode == SensitiveMask
+	}
+	return l.protos.field(fd, m.Get(fd)), true
+}
+
+// fieldsServerStream captures selected fields from the messages of a server stream.
+type fieldsServerStream struct {
+	grpc.ServerStream
+	l    *Logger
+	call *callState
+}
+
+// RecvMsg captures the first request received on the stream.
+func (s *fieldsServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.l.captureRequest(s.call, m)
+	}
+	return err
+}
+
+// SendMsg captures the first response sent on the stream.
+func (s *fieldsServerStream) SendMsg(m any) error {
+	s.l.captureResponse(s.call, m)
+	return s.ServerStream.SendMsg(m)
+}
+
+// fieldsClientStream captures selected fields from the messages of a client stream.
+type fieldsClientStream struct {
+	grpc.ClientStream
+	l    *Logger
+	call *callState
+}
+
+// SendMsg captures the first request sent on the stream.
+func (s *fieldsClientStream) SendMsg(m any) error {
+	s.l.captureRequest(s.call, m)
+	return s.ClientStream.SendMsg(m)
+}
+
+// RecvMsg captures the first response received on the stream.
+func (s *fieldsClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		s.l.captureResponse(s.call, m)
+	}
+	return err
+}
+
+// fieldsHandler wraps handler so the response is captured before the logging
+// middleware writes its finish entry.
+func (l *Logger) fieldsHandler(call *callState, handler grpc.UnaryHandler) grpc.UnaryHandler {
+	if call.fields == nil {
+		return handler
+	}
+	return func(ctx context.Context, req any) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			l.captureResponse(call, resp)
+		}
+		return resp, err
+	}
+}
+
+// fieldsInvoker wraps invoker so the reply is captured before the logging
+// middleware writes its finish entry.
+func (l *Logger) fieldsInvoker(call *callState, invoker grpc.UnaryInvoker) grpc.UnaryInvoker {
+	if call.fields == nil {
+		return invoker
+	}
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			l.captureResponse(call, reply)
+		}
+		return err
+	}
+}
+
+// fieldsStreamer wraps streamer so the returned stream captures selected
+// fields before the logging middleware sees each message.
+func (l *Logger) fieldsStreamer(call *callState, streamer grpc.Streamer) grpc.Streamer {
+	if call.fields == nil {
+		return streamer
+	}
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return cs, err
+		}
+		return &fieldsClientStream{ClientStream: cs, l: l, call: call}, nil
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const createOrderMethod = "/slogcpadapter.svc.Orders/Create"
+
+// TestNewPayloadFieldSelectorValidates verifies unknown methods and paths fail at construction.
+func TestNewPayloadFieldSelectorValidates(t *testing.T) {
+	testOrdersService()
+	tests := []struct {
+		name   string
+		method string
+		fields PayloadFields
+	}{
+		{"unknown-service", "/nope.Svc/Create", PayloadFields{}},
+		{"unknown-method", "/slogcpadapter.svc.Orders/Delete", PayloadFields{}},
+		{"typo", createOrderMethod, PayloadFields{Request: []string{"customer_idd"}}},
+		{"json-name", createOrderMethod, PayloadFields{Request: []string{"customerId"}}},
+		{"through-scalar", createOrderMethod, PayloadFields{Request: []string{"customer_id.x"}}},
+		{"response-typo", createOrderMethod, PayloadFields{Response: []string{"order.state"}}},
+	}
+	for _, tt := range tests {
+		if _, err := NewPayloadFieldSelector(map[string]PayloadFields{tt.method: tt.fields}); err == nil {
+			t.Fatalf("%s: expected error", tt.name)
+		}
+	}
+}
+
+// TestPayloadFieldsUnaryServer verifies selected fields are attached to every call entry.
+func TestPayloadFieldsUnaryServer(t *testing.T) {
+	testOrdersService()
+	selector, err := NewPayloadFieldSelector(map[string]PayloadFields{
+		createOrderMethod: {
+			Request:  []string{"customer_id", "order.id", "order.status", "secret"},
+			Response: []string{"order.status", "order.items"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewPayloadFieldSelector: %v", err)
+	}
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithPayloadFields(selector), WithPayloadFields(nil))
+
+	req := newTestOrderMessage("CreateOrderRequest")
+	setFields(req, "customer_id", "c-1", "secret", "hunter2")
+	setFields(req.Mutable(req.Descriptor().Fields().ByName("order")).Message(), "id", "o-9")
+	resp := newTestOrderMessage("CreateOrderResponse")
+	order := resp.Mutable(resp.Descriptor().Fields().ByName("order")).Message()
+	setFields(order, "status", "PENDING")
+	order.Mutable(order.Descriptor().Fields().ByName("items")).List().Append(protoreflect.ValueOfString("book"))
+
+	interceptor := logger.UnaryServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.StartCall, grpc_logging.FinishCall))
+	info := &grpc.UnaryServerInfo{FullMethod: createOrderMethod}
+	if _, err := interceptor(context.Background(), req, info, func(context.Context, any) (any, error) { return resp, nil }); err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+
+	if len(rec.records) != 2 {
+		t.Fatalf("expected start and finish records, got %d", len(rec.records))
+	}
+	wantRequest := map[string]any{"customer_id": "c-1", "order.id": "o-9"}
+	for i, r := range rec.records {
+		if got := valueToAny(attrValue(t, r, RequestFieldsKey)); !reflect.DeepEqual(got, wantRequest) {
+			t.Fatalf("record %d: expected request fields %v, got %v", i, wantRequest, got)
+		}
+	}
+	if _, ok := collectAttrs(rec.records[0])[ResponseFieldsKey]; ok {
+		t.Fatalf("expected no response fields before the handler ran")
+	}
+	wantResponse := map[string]any{"order.status": "PENDING", "order.items": []any{"book"}}
+	if got := valueToAny(attrValue(t, rec.records[1], ResponseFieldsKey)); !reflect.DeepEqual(got, wantResponse) {
+		t.Fatalf("expected response fields %v, got %v", wantResponse, got)
+	}
+}
+
+// TestPayloadFieldsStreamCapturesFirstMessage verifies streams keep the first request they see.
+func TestPayloadFieldsStreamCapturesFirstMessage(t *testing.T) {
+	testOrdersService()
+	selector, err := NewPayloadFieldSelector(map[string]PayloadFields{createOrderMethod: {Request: []string{"customer_id"}}})
+	if err != nil {
+		t.Fatalf("NewPayloadFieldSelector: %v", err)
+	}
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithPayloadFields(selector))
+
+	first := newTestOrderMessage("CreateOrderRequest")
+	setFields(first, "customer_id", "c-1")
+	second := newTestOrderMessage("CreateOrderRequest")
+	setFields(second, "customer_id", "c-2")
+
+	interceptor := logger.StreamServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.FinishCall))
+	info := &grpc.StreamServerInfo{FullMethod: createOrderMethod, IsClientStream: true}
+	ss := &queueServerStream{fakeServerStream: fakeServerStream{ctx: context.Background()}, in: []proto.Message{first, second}}
+	err = interceptor(nil, ss, info, func(_ any, stream grpc.ServerStream) error {
+		for range 2 {
+			if err := stream.RecvMsg(newTestOrderMessage("CreateOrderRequest")); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+	got := valueToAny(attrValue(t, rec.records[0], RequestFieldsKey))
+	if want := map[string]any{"customer_id": "c-1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected first request fields %v, got %v", want, got)
+	}
+}
+
+// queueServerStream is a fakeServerStream whose RecvMsg returns queued messages.
+type queueServerStream struct {
+	fakeServerStream
+	in []proto.Message
+}
+
+// RecvMsg copies the next queued message into m.
+func (s *queueServerStream) RecvMsg(m any) error {
+	if len(s.in) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(proto.Message), s.in[0])
+	s.in = s.in[1:]
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+
+	"google.golang.org/grpc/peer"
+)
+
+// PeerCredKey is the group holding the credentials of a Unix domain socket peer.
+const PeerCredKey = "peer.unix"
+
+// PeerCredAddr is the remote address of a connection accepted by a listener
+// from [NewPeerCredListener]. It carries the peer's SO_PEERCRED credentials,
+// which the Logger's server interceptors log under [PeerCredKey].
+type PeerCredAddr struct {
+	net.Addr
+	PID int32
+	UID uint32
+	GID uint32
+	// Process is the peer's process name from /proc, if it was resolved.
+	Process string
+}
+
+// String returns the underlying address or, for the unnamed client ends of
+// Unix sockets that report "" or "@", an address naming the peer process.
+func (a *PeerCredAddr) String() string {
+	if s := a.Addr.String(); s != "" && s != "@" {
+		return s
+	}
+	return fmt.Sprintf("@pid=%d", a.PID)
+}
+
+// NewPeerCredListener wraps a Unix domain socket listener so that accepted
+// connections report their peer's pid, uid and gid through [PeerCredAddr].
+// With resolveProcessName, the peer's process name is read from
+// /proc/<pid>/comm once per connection. Connections whose credentials cannot
+// be read keep their original address. SO_PEERCRED is only available on
+// Linux; elsewhere the listener passes connections through unchanged.
+//
+// Example:
+//
+//	lis, _ := net.Listen("unix", "/run/sidecar.sock")
+//	server.Serve(slogcpadapter.NewPeerCredListener(lis, true))
+func NewPeerCredListener(lis net.Listener, resolveProcessName bool) net.Listener {
+	return &peerCredListener{Listener: lis, resolveProcessName: resolveProcessName}
+}
+
+type peerCredListener struct {
+	net.Listener
+	resolveProcessName bool
+}
+
+// Accept waits for the next connection and attaches its peer credentials.
+func (l *peerCredListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return conn, err
+	}
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return conn, nil
+	}
+	addr, err := readPeerCred(unixConn)
+	if err != nil {
+		return conn, nil //nolint:nilerr // credentials are best effort; the connection is still usable.
+	}
+	if l.resolveProcessName {
+		addr.Process = processName(addr.PID)
+	}
+	return &peerCredConn{UnixConn: unixConn, remote: addr}, nil
+}
+
+// peerCredConn reports a PeerCredAddr as its remote address.
+type peerCredConn struct {
+	*net.UnixConn
+	remote *PeerCredAddr
+}
+
+// RemoteAddr returns the peer's address with its credentials.
+func (c *peerCredConn) RemoteAddr() net.Addr { return c.remote }
+
+// peerCredAttrs returns the credential attributes of the peer in ctx, or nil.
+func peerCredAttrs(ctx context.Context) []slog.Attr {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	addr, ok := p.Addr.(*PeerCredAddr)
+	if !ok {
+		return nil
+	}
+	attrs := []slog.Attr{
+		slog.Int64("pid", int64(addr.PID)),
+		slog.Uint64("uid", uint64(addr.UID)),
+		slog.Uint64("gid", uint64(addr.GID)),
+	}
+	if addr.Process != "" {
+		attrs = append(attrs, slog.String("process", addr.Process))
+	}
+	return attrs
+}
@@ -0,0 +1,55 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package slogcpadapter
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// readPeerCred reads SO_PEERCRED from conn.
+func readPeerCred(conn *net.UnixConn) (*PeerCredAddr, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return nil, fmt.Errorf("slogcpadapter: peer credentials: %w", err)
+	}
+	var (
+		cred    *syscall.Ucred
+		credErr error
+	)
+	if err := raw.Control(func(fd uintptr) {
+		cred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil {
+		return nil, fmt.Errorf("slogcpadapter: peer credentials: %w", err)
+	}
+	if credErr != nil {
+		return nil, fmt.Errorf("slogcpadapter: peer credentials: %w", credErr)
+	}
+	return &PeerCredAddr{Addr: conn.RemoteAddr(), PID: cred.Pid, UID: cred.Uid, GID: cred.Gid}, nil
+}
+
+// processName returns the command name of pid from /proc, or "".
+func processName(pid int32) string {
+	comm, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(comm))
+}
@@ -0,0 +1,57 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package slogcpadapter
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestPeerCredListenerReadsCredentials verifies accepted Unix connections carry SO_PEERCRED.
+func TestPeerCredListenerReadsCredentials(t *testing.T) {
+	lis, err := net.Listen("unix", filepath.Join(t.TempDir(), "s.sock"))
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	wrapped := NewPeerCredListener(lis, true)
+	defer wrapped.Close()
+
+	client, err := net.Dial("unix", lis.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer client.Close()
+
+	conn, err := wrapped.Accept()
+	if err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	defer conn.Close()
+
+	addr, ok := conn.RemoteAddr().(*PeerCredAddr)
+	if !ok {
+		t.Fatalf("expected *PeerCredAddr, got %T", conn.RemoteAddr())
+	}
+	if addr.PID != int32(os.Getpid()) || addr.UID != uint32(os.Getuid()) {
+		t.Fatalf("expected own pid and uid, got %+v", addr)
+	}
+	if addr.Process == "" {
+		t.Fatalf("expected process name from /proc")
+	}
+}
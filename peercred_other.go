@@ -0,0 +1,30 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+
+package slogcpadapter
+
+import (
+	"errors"
+	"net"
+)
+
+// readPeerCred reports that SO_PEERCRED is unavailable on this platform.
+func readPeerCred(*net.UnixConn) (*PeerCredAddr, error) {
+	return nil, errors.New("slogcpadapter: peer credentials are only supported on linux")
+}
+
+// processName is unavailable without /proc.
+func processName(int32) string { return "" }
@@ -0,0 +1,41 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"log/slog"
+	"net"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/peer"
+)
+
+// TestPeerCredAttrsLogged verifies Unix socket peer credentials are logged on server entries.
+func TestPeerCredAttrsLogged(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)))
+	addr := &PeerCredAddr{Addr: &net.UnixAddr{Name: "@", Net: "unix"}, PID: 42, UID: 1000, GID: 100, Process: "sidecar"}
+
+	records := runWithPeer(t, logger, rec, &peer.Peer{Addr: addr})
+	got := valueToAny(attrValue(t, records[0], PeerCredKey))
+	want := map[string]any{"pid": int64(42), "uid": uint64(1000), "gid": uint64(100), "process": "sidecar"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected peer credentials %v, got %v", want, got)
+	}
+	if collectAttrs(records[0])["peer.address"] != "@pid=42" {
+		t.Fatalf("expected unnamed socket address to name the peer process, got %v", collectAttrs(records[0])["peer.address"])
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/alts"
+	"google.golang.org/grpc/peer"
+)
+
+// PeerIdentityKey is the group describing how the peer authenticated.
+const PeerIdentityKey = "peer.identity"
+
+// WithPeerIdentity makes the Logger's server interceptors log the peer's
+// transport credentials under [PeerIdentityKey]: for TLS the version, cipher
+// suite, SNI and, with mTLS, the client certificate's subject, SANs, SPIFFE ID,
+// issuer and expiry; for ALTS the peer and local service accounts and the
+// negotiated protocols; for local credentials the security level and network.
+func WithPeerIdentity() LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.peerIdentity = true
+	}
+}
+
+// WithCertExpiryWarning makes the Logger log a warning, once per certificate,
+// when a client presents a certificate that expires within window. It implies
+// [WithPeerIdentity]. A non-positive window is ignored.
+func WithCertExpiryWarning(window time.Duration) LoggerOption {
+	return func(cfg *loggerConfig) {
+		if window > 0 {
+			cfg.peerIdentity = true
+			cfg.certExpiryWindow = window
+		}
+	}
+}
+
+// minCertSweep is the number of remembered certificates at which certWarnings
+// first forgets expired ones.
+const minCertSweep = 1024
+
+// certWarnings remembers which certificates already triggered an expiry
+// warning. Certificates are forgotten once they have expired, so short-lived
+// certificates do not accumulate.
+type certWarnings struct {
+	window  time.Duration
+	mu      sync.Mutex
+	seen    map[string]time.Time // issuer + serial -> NotAfter
+	sweepAt int
+}
+
+// newCertWarnings returns an empty certWarnings for window.
+func newCertWarnings(window time.Duration) *certWarnings {
+	return &certWarnings{window: window, seen: make(map[string]time.Time), sweepAt: minCertSweep}
+}
+
+// first reports whether cert is reported for the first time at now and
+// remembers it. Expired certificates are forgotten whenever the set has
+// doubled since the last sweep, which keeps the cost per certificate constant.
+func (w *certWarnings) first(cert *x509.Certificate, now time.Time) bool {
+	key := cert.Issuer.String() + "/" + cert.SerialNumber.String()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.seen[key]; ok {
+		return false
+	}
+	if len(w.seen) >= w.sweepAt {
+		for k, notAfter := range w.seen {
+			if now.After(notAfter) {
+				delete(w.seen, k)
+			}
+		}
+		w.sweepAt = max(2*len(w.seen), minCertSweep)
+	}
+	w.seen[key] = cert.NotAfter
+	return true
+}
+
+// peerIdentity returns the identity attributes of the peer in ctx, or nil.
+func (l *Logger) peerIdentity(ctx context.Context) []slog.Attr {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return nil
+	}
+	switch info := p.AuthInfo.(type) {
+	case credentials.TLSInfo:
+		attrs := tlsIdentity(info.State)
+		if cert := clientCert(info.State); cert != nil {
+			l.warnExpiringCert(ctx, cert, attrs)
+		}
+		return attrs
+	case alts.AuthInfo:
+		return appendSecurityLevel([]slog.Attr{
+			slog.String("type", "alts"),
+			slog.String("peer_service_account", info.PeerServiceAccount()),
+			slog.String("local_service_account", info.LocalServiceAccount()),
+			slog.String("application_protocol", info.ApplicationProtocol()),
+			slog.String("record_protocol", info.RecordProtocol()),
+		}, p.AuthInfo)
+	default:
+		attrs := appendSecurityLevel([]slog.Attr{slog.String("type", info.AuthType())}, info)
+		if p.Addr != nil {
+			attrs = append(attrs, slog.String("network", p.Addr.Network()))
+		}
+		return attrs
+	}
+}
+
+// appendSecurityLevel adds the security level of credentials that embed
+// credentials.CommonAuthInfo, as the built-in ones do.
+func appendSecurityLevel(attrs []slog.Attr, info credentials.AuthInfo) []slog.Attr {
+	common, ok := info.(interface {
+		GetCommonAuthInfo() credentials.CommonAuthInfo
+	})
+	if !ok {
+		return attrs
+	}
+	return append(attrs, slog.String("security_level", common.GetCommonAuthInfo().SecurityLevel.String()))
+}
+
+// tlsIdentity describes a TLS connection and its client certificate, if any.
+func tlsIdentity(state tls.ConnectionState) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("type", "tls"),
+		slog.String("tls_version", tls.VersionName(state.Version)),
+		slog.String("cipher_suite", tls.CipherSuiteName(state.CipherSuite)),
+	}
+	if state.ServerName != "" {
+		attrs = append(attrs, slog.String("sni", state.ServerName))
+	}
+	cert := clientCert(state)
+	attrs = append(attrs, slog.Bool("mtls", cert != nil))
+	if cert == nil {
+		return attrs
+	}
+	attrs = append(attrs,
+		slog.String("subject", cert.Subject.String()),
+		slog.String("issuer", cert.Issuer.String()),
+		slog.String("not_after", cert.NotAfter.UTC().Format(time.RFC3339)),
+	)
+	if san := certSANs(cert); len(san) > 0 {
+		attrs = append(attrs, slog.Any("sans", san))
+	}
+	if id := spiffeID(cert); id != "" {
+		attrs = append(attrs, slog.String("spiffe_id", id))
+	}
+	return attrs
+}
+
+// clientCert returns the leaf certificate the client presented, or nil.
+func clientCert(state tls.ConnectionState) *x509.Certificate {
+	if len(state.PeerCertificates) == 0 {
+		return nil
+	}
+	return state.PeerCertificates[0]
+}
+
+// certSANs lists the subject alternative names of cert.
+func certSANs(cert *x509.Certificate) []string {
+	sans := append([]string(nil), cert.DNSNames...)
+	sans = append(sans, cert.EmailAddresses...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	return sans
+}
+
+// spiffeID returns the SPIFFE ID carried as a URI SAN, or "".
+func spiffeID(cert *x509.Certificate) string {
+	for _, uri := range cert.URIs {
+		if uri.Scheme == "spiffe" {
+			return uri.String()
+		}
+	}
+	return ""
+}
+
+// warnExpiringCert logs a warning the first time cert is seen within the
+// configured expiry window.
+func (l *Logger) warnExpiringCert(ctx context.Context, cert *x509.Certificate, identity []slog.Attr) {
+	w := l.certWarnings
+	if w == nil {
+		return
+	}
+	now := l.now()
+	remaining := cert.NotAfter.Sub(now)
+	if remaining > w.window {
+		return
+	}
+	if !w.first(cert, now) {
+		return
+	}
+	msg := "client certificate expiring soon"
+	attrs := append([]slog.Attr{
+		slog.String("grpc.component", "server"),
+		slog.Float64("cert_expires_in_ms", durationMillis(remaining)),
+	}, l.redact([]slog.Attr{{Key: PeerIdentityKey, Value: slog.GroupValue(identity...)}})...)
+	l.write(ctx, now, slog.LevelWarn, msg, l.sourcePC(nil), l.appendInsertID(nil, msg, attrs))
+}
@@ -0,0 +1,236 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/alts"
+	"google.golang.org/grpc/peer"
+)
+
+// newTestCert returns a self-signed client certificate with a SPIFFE URI SAN.
+func newTestCert(t *testing.T, notAfter time.Time) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	spiffe, _ := url.Parse("spiffe://example.org/ns/default/sa/billing")
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(7),
+		Subject:      pkix.Name{CommonName: "billing"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		DNSNames:     []string{"billing.internal"},
+		URIs:         []*url.URL{spiffe},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate: %v", err)
+	}
+	return cert
+}
+
+// runWithPeer runs one unary server call from p and returns the records logged.
+func runWithPeer(t *testing.T, logger *Logger, rec *recordingHandler, p *peer.Peer) []slog.Record {
+	t.Helper()
+	interceptor := logger.UnaryServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.FinishCall))
+	ctx := peer.NewContext(context.Background(), p)
+	_, _ = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Get"}, func(context.Context, any) (any, error) { return nil, nil })
+	return rec.records
+}
+
+// TestPeerIdentityMTLS verifies TLS and client certificate details are logged.
+func TestPeerIdentityMTLS(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithPeerIdentity())
+	cert := newTestCert(t, time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC))
+	info := credentials.TLSInfo{State: tls.ConnectionState{
+		Version:          tls.VersionTLS13,
+		CipherSuite:      tls.TLS_AES_128_GCM_SHA256,
+		ServerName:       "api.example.com",
+		PeerCertificates: []*x509.Certificate{cert},
+	}}
+
+	records := runWithPeer(t, logger, rec, &peer.Peer{Addr: &net.TCPAddr{}, AuthInfo: info})
+	got := valueToAny(attrValue(t, records[0], PeerIdentityKey))
+	want := map[string]any{
+		"type":         "tls",
+		"tls_version":  "TLS 1.3",
+		"cipher_suite": "TLS_AES_128_GCM_SHA256",
+		"sni":          "api.example.com",
+		"mtls":         true,
+		"subject":      "CN=billing",
+		"issuer":       "CN=billing",
+		"not_after":    "2030-01-02T03:04:05Z",
+		"sans":         []string{"billing.internal", "spiffe://example.org/ns/default/sa/billing"},
+		"spiffe_id":    "spiffe://example.org/ns/default/sa/billing",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected identity %v, got %v", want, got)
+	}
+}
+
+// TestPeerIdentityCertExpiryWarning verifies expiring certificates are reported once.
+func TestPeerIdentityCertExpiryWarning(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithCertExpiryWarning(72*time.Hour), WithCertExpiryWarning(0))
+	cert := newTestCert(t, time.Now().Add(24*time.Hour))
+	p := &peer.Peer{Addr: &net.TCPAddr{}, AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}}}
+
+	runWithPeer(t, logger, rec, p)
+	records := runWithPeer(t, logger, rec, p)
+
+	warnings := 0
+	for _, r := range records {
+		if r.Message == "client certificate expiring soon" {
+			warnings++
+			if r.Level != slog.LevelWarn {
+				t.Fatalf("expected WARN, got %v", r.Level)
+			}
+		}
+	}
+	if warnings != 1 {
+		t.Fatalf("expected one expiry warning, got %d", warnings)
+	}
+}
+
+// fakeALTSInfo is an alts.AuthInfo with fixed accounts.
+type fakeALTSInfo struct {
+	alts.AuthInfo
+	level credentials.SecurityLevel
+}
+
+func (i fakeALTSInfo) GetCommonAuthInfo() credentials.CommonAuthInfo {
+	return credentials.CommonAuthInfo{SecurityLevel: i.level}
+}
+
+func (fakeALTSInfo) AuthType() string            { return "alts" }
+func (fakeALTSInfo) PeerServiceAccount() string  { return "caller@project.iam.gserviceaccount.com" }
+func (fakeALTSInfo) LocalServiceAccount() string { return "server@project.iam.gserviceaccount.com" }
+func (fakeALTSInfo) ApplicationProtocol() string { return "grpc" }
+func (fakeALTSInfo) RecordProtocol() string      { return "ALTSRP_GCM_AES128_REKEY" }
+
+// fakeLocalInfo mirrors the AuthInfo of local credentials.
+type fakeLocalInfo struct{ credentials.CommonAuthInfo }
+
+func (fakeLocalInfo) AuthType() string { return "local" }
+
+// TestPeerIdentityALTSAndLocal verifies ALTS and local credentials log their own shapes.
+func TestPeerIdentityALTSAndLocal(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithPeerIdentity())
+
+	altsInfo := fakeALTSInfo{level: credentials.PrivacyAndIntegrity}
+	records := runWithPeer(t, logger, rec, &peer.Peer{Addr: &net.TCPAddr{}, AuthInfo: altsInfo})
+	altsIdentity, _ := valueToAny(attrValue(t, records[0], PeerIdentityKey)).(map[string]any)
+	if altsIdentity["type"] != "alts" || altsIdentity["peer_service_account"] != "caller@project.iam.gserviceaccount.com" ||
+		altsIdentity["security_level"] != "PrivacyAndIntegrity" {
+		t.Fatalf("unexpected ALTS identity %v", altsIdentity)
+	}
+
+	local := fakeLocalInfo{credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity}}
+	records = runWithPeer(t, logger, rec, &peer.Peer{Addr: &net.UnixAddr{Name: "/tmp/s", Net: "unix"}, AuthInfo: local})
+	got := valueToAny(attrValue(t, records[1], PeerIdentityKey))
+	want := map[string]any{"type": "local", "security_level": "PrivacyAndIntegrity", "network": "unix"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected local identity %v, got %v", want, got)
+	}
+}
+
+// TestPeerIdentityRedacted verifies redaction rules reach the identity group.
+func TestPeerIdentityRedacted(t *testing.T) {
+	redactor, err := NewRedactor(nil, RedactionRule{Key: "sans", Mode: RedactDrop})
+	if err != nil {
+		t.Fatalf("NewRedactor: %v", err)
+	}
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithRedactor(redactor), WithPeerIdentity())
+	info := credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{newTestCert(t, time.Now().Add(time.Hour))}}}
+
+	records := runWithPeer(t, logger, rec, &peer.Peer{Addr: &net.TCPAddr{}, AuthInfo: info})
+	identity, _ := valueToAny(attrValue(t, records[0], PeerIdentityKey)).(map[string]any)
+	if _, ok := identity["sans"]; ok || identity["mtls"] != true {
+		t.Fatalf("expected sans to be dropped, got %v", identity)
+	}
+}
+
+// TestCertWarningsForgetExpired verifies expired certificates do not accumulate.
+func TestCertWarningsForgetExpired(t *testing.T) {
+	w := newCertWarnings(time.Hour)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 3 * minCertSweep {
+		cert := &x509.Certificate{SerialNumber: big.NewInt(int64(i)), NotAfter: now.Add(time.Minute)}
+		if !w.first(cert, now) {
+			t.Fatalf("expected certificate %d to be new", i)
+		}
+		now = now.Add(time.Minute)
+	}
+	if n := len(w.seen); n > 2*minCertSweep {
+		t.Fatalf("expected expired certificates to be forgotten, %d remembered", n)
+	}
+	live := &x509.Certificate{SerialNumber: big.NewInt(3*minCertSweep - 1), NotAfter: now}
+	if w.first(live, now) {
+		t.Fatalf("expected an unexpired certificate to be remembered")
+	}
+}
+
+// TestPeerIdentityCertExpiryWarningRedacted verifies redaction rules reach the
+// identity group of the expiry warning.
+func TestPeerIdentityCertExpiryWarningRedacted(t *testing.T) {
+	redactor, err := NewRedactor(nil, RedactionRule{Key: "subject", Mode: RedactDrop})
+	if err != nil {
+		t.Fatalf("NewRedactor: %v", err)
+	}
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithRedactor(redactor), WithCertExpiryWarning(72*time.Hour))
+	cert := newTestCert(t, time.Now().Add(24*time.Hour))
+	p := &peer.Peer{Addr: &net.TCPAddr{}, AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}}}
+
+	var warning *slog.Record
+	for i, r := range runWithPeer(t, logger, rec, p) {
+		if r.Message == "client certificate expiring soon" {
+			warning = &rec.records[i]
+		}
+	}
+	if warning == nil {
+		t.Fatalf("expected an expiry warning")
+	}
+	identity, _ := valueToAny(attrValue(t, *warning, PeerIdentityKey)).(map[string]any)
+	if _, ok := identity["subject"]; ok || identity["mtls"] != true {
+		t.Fatalf("expected subject to be dropped from the warning, got %v", identity)
+	}
+}
@@ -0,0 +1,416 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// SensitiveFieldMode selects how proto fields marked sensitive are rendered.
+type SensitiveFieldMode int
+
+const (
+	// SensitiveOmit leaves sensitive fields out of the rendered payload.
+	SensitiveOmit SensitiveFieldMode = iota
+	// SensitiveMask keeps sensitive fields but replaces their value with [RedactedValue].
+	SensitiveMask
+)
+
+// WithSensitiveFieldMode makes [NewLogger] omit or mask proto fields marked
+// with the debug_redact field option or with an option registered through
+// [WithSensitiveFieldOption]. The default is [SensitiveOmit].
+func WithSensitiveFieldMode(mode SensitiveFieldMode) LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.sensitiveMode = mode
+	}
+}
+
+// WithSensitiveFieldOption marks proto fields carrying the boolean field option
+// ext, for example (ourco.sensitive) = true, as sensitive in payload logs.
+// It may be given several times. A nil extension is ignored.
+//
+// Example:
+//
+//	adapter := slogcpadapter.NewLogger(handler,
+//		slogcpadapter.WithSensitiveFieldOption(ourcopb.E_Sensitive),
+//	)
+func WithSensitiveFieldOption(ext protoreflect.ExtensionType) LoggerOption {
+	return func(cfg *loggerConfig) {
+		if ext != nil {
+			cfg.sensitiveOptions = append(cfg.sensitiveOptions, ext)
+		}
+	}
+}
+
+// WithEmitUnpopulated makes rendered proto messages include unpopulated fields
+// with their zero value, or null for fields with presence, as
+// protojson.MarshalOptions.EmitUnpopulated does. Unset oneof members stay out.
+func WithEmitUnpopulated() LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.emitUnpopulated = true
+	}
+}
+
+// WithProtoFieldNames makes rendered proto messages use the field names from the
+// .proto file, such as user_id, instead of their lowerCamelCase JSON names.
+func WithProtoFieldNames() LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.protoFieldNames = true
+	}
+}
+
+// protoRenderer converts proto.Message and enum values into slog values shaped
+// like their protojson encoding, honoring sensitivity annotations.
+// Per-descriptor field plans are cached.
+type protoRenderer struct {
+	mode            SensitiveFieldMode
+	extensions      []protoreflect.ExtensionType
+	emitUnpopulated bool
+	protoNames      bool
+	plans           sync.Map // protoreflect.MessageDescriptor -> *messagePlan
+}
+
+// messagePlan is the cached rendering plan for one message descriptor.
+type messagePlan struct {
+	fields []fieldPlan
+}
+
+type fieldPlan struct {
+	fd        protoreflect.FieldDescriptor
+	key       string
+	sensitive bool
+}
+
+// wellKnownJSON lists the well-known types whose protojson form is not a plain
+// object of their fields. They are rendered through protojson itself.
+var wellKnownJSON = map[protoreflect.FullName]bool{
+	"google.protobuf.Timestamp":   true,
+	"google.protobuf.Duration":    true,
+	"google.protobuf.FieldMask":   true,
+	"google.protobuf.Struct":      true,
+	"google.protobuf.Value":       true,
+	"google.protobuf.ListValue":   true,
+	"google.protobuf.Empty":       true,
+	"google.protobuf.BoolValue":   true,
+	"google.protobuf.BytesValue":  true,
+	"google.protobuf.DoubleValue": true,
+	"google.protobuf.FloatValue":  true,
+	"google.protobuf.Int32Value":  true,
+	"google.protobuf.Int64Value":  true,
+	"google.protobuf.StringValue": true,
+	"google.protobuf.UInt32Value": true,
+	"google.protobuf.UInt64Value": true,
+}
+
+// newProtoRenderer returns a renderer for the payload settings in cfg.
+func newProtoRenderer(cfg *loggerConfig) *protoRenderer {
+	return &protoRenderer{
+		mode:            cfg.sensitiveMode,
+		extensions:      cfg.sensitiveOptions,
+		emitUnpopulated: cfg.emitUnpopulated,
+		protoNames:      cfg.protoFieldNames,
+	}
+}
+
+// renderAttrs replaces proto.Message values in attrs, including inside groups,
+// with their rendered form.
+func (r *protoRenderer) renderAttrs(attrs []slog.Attr) []slog.Attr {
+	for i, a := range attrs {
+		attrs[i].Value = r.renderValue(a.Value)
+	}
+	return attrs
+}
+
+// renderValue returns v with any proto.Message rendered as a group and any
+// proto enum rendered as its value name.
+func (r *protoRenderer) renderValue(v slog.Value) slog.Value {
+	switch v.Kind() {
+	case slog.KindAny:
+		switch x := v.Any().(type) {
+		case proto.Message:
+			if x != nil {
+				return r.message(x.ProtoReflect())
+			}
+		case protoreflect.Enum:
+			if x != nil {
+				return enumValue(x.Descriptor(), x.Number())
+			}
+		}
+	case slog.KindGroup:
+		group := v.Group()
+		out := make([]slog.Attr, len(group))
+		copy(out, group)
+		return slog.GroupValue(r.renderAttrs(out)...)
+	}
+	return v
+}
+
+// plan returns the cached rendering plan for md, building it on first use.
+func (r *protoRenderer) plan(md protoreflect.MessageDescriptor) *messagePlan {
+	if p, ok := r.plans.Load(md); ok {
+		return p.(*messagePlan)
+	}
+	fields := md.Fields()
+	p := &messagePlan{fields: make([]fieldPlan, fields.Len())}
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		key := fd.JSONName()
+		if r.protoNames {
+			key = fd.TextName()
+		}
+		p.fields[i] = fieldPlan{fd: fd, key: key, sensitive: r.isSensitive(fd)}
+	}
+	actual, _ := r.plans.LoadOrStore(md, p)
+	return actual.(*messagePlan)
+}
+
+// isSensitive reports whether fd is marked debug_redact or carries one of the
+// configured sensitivity options.
+func (r *protoRenderer) isSensitive(fd protoreflect.FieldDescriptor) bool {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil {
+		return false
+	}
+	if opts.GetDebugRedact() {
+		return true
+	}
+	for _, ext := range r.extensions {
+		if !proto.HasExtension(opts, ext) {
+			continue
+		}
+		if set, ok := proto.GetExtension(opts, ext).(bool); ok && set {
+			return true
+		}
+	}
+	return false
+}
+
+// message renders m as a group of its populated fields, or as the special
+// protojson form of a well-known type. A message without populated fields
+// renders as an empty object, since most handlers drop an empty group.
+func (r *protoRenderer) message(m protoreflect.Message) slog.Value {
+	if !m.IsValid() {
+		return slog.AnyValue(nil)
+	}
+	name := m.Descriptor().FullName()
+	if name == "google.protobuf.Any" {
+		return r.anyMessage(m)
+	}
+	if wellKnownJSON[name] {
+		if v, ok := wellKnownValue(m); ok {
+			return v
+		}
+	}
+	plan := r.plan(m.Descriptor())
+	attrs := make([]slog.Attr, 0, len(plan.fields))
+	for _, f := range plan.fields {
+		if v, ok := r.planned(m, f); ok {
+			attrs = append(attrs, slog.Attr{Key: f.key, Value: v})
+		}
+	}
+	if len(attrs) == 0 {
+		return slog.AnyValue(map[string]any{})
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// planned renders field f of m, reporting false when it is left out.
+func (r *protoRenderer) planned(m protoreflect.Message, f fieldPlan) (slog.Value, bool) {
+	if !m.Has(f.fd) {
+		if !r.emitUnpopulated || f.sensitive || f.fd.ContainingOneof() != nil && !f.fd.ContainingOneof().IsSynthetic() {
+			return slog.Value{}, false
+		}
+		if f.fd.HasPresence() {
+			return slog.AnyValue(nil), true
+		}
+	}
+	if f.sensitive {
+		return slog.StringValue(RedactedValue), r.mode == SensitiveMask
+	}
+	return r.field(f.fd, m.Get(f.fd)), true
+}
+
+// wellKnownValue renders a well-known type through protojson and converts the
+// resulting JSON into slog values. It reports false if protojson rejects m,
+// for example a Timestamp out of range.
+func wellKnownValue(m protoreflect.Message) (slog.Value, bool) {
+	raw, err := protojson.Marshal(m.Interface())
+	if err != nil {
+		return slog.Value{}, false
+	}
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return slog.Value{}, false
+	}
+	return jsonValue(decoded), true
+}
+
+// jsonValue converts a decoded JSON value into a slog value, turning objects
+// into groups with sorted keys.
+func jsonValue(v any) slog.Value {
+	obj, ok := v.(map[string]any)
+	if !ok || len(obj) == 0 {
+		return slog.AnyValue(v)
+	}
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	attrs := make([]slog.Attr, len(keys))
+	for i, k := range keys {
+		attrs[i] = slog.Attr{Key: k, Value: jsonValue(obj[k])}
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// anyMessage renders a google.protobuf.Any with its "@type" and unpacked fields.
+// Payloads of types missing from the global registry keep their raw bytes.
+func (r *protoRenderer) anyMessage(m protoreflect.Message) slog.Value {
+	fields := m.Descriptor().Fields()
+	typeURL := m.Get(fields.ByName("type_url")).String()
+	typeAttr := slog.String("@type", typeURL)
+
+	anyMsg, ok := m.Interface().(*anypb.Any)
+	if !ok {
+		return slog.GroupValue(typeAttr)
+	}
+	inner, err := anyMsg.UnmarshalNew()
+	if err != nil {
+		return slog.GroupValue(typeAttr, slog.String("value", base64.StdEncoding.EncodeToString(anyMsg.GetValue())))
+	}
+	rendered := r.message(inner.ProtoReflect())
+	if wellKnownJSON[inner.ProtoReflect().Descriptor().FullName()] {
+		// protojson nests well-known types under "value" rather than inlining them.
+		return slog.GroupValue(typeAttr, slog.Attr{Key: "value", Value: rendered})
+	}
+	if rendered.Kind() != slog.KindGroup {
+		// An empty message has no fields to inline.
+		return slog.GroupValue(typeAttr)
+	}
+	return slog.GroupValue(append([]slog.Attr{typeAttr}, rendered.Group()...)...)
+}
+
+// field renders the value of fd, which may be a list or map.
+func (r *protoRenderer) field(fd protoreflect.FieldDescriptor, v protoreflect.Value) slog.Value {
+	switch {
+	case fd.IsList():
+		list := v.List()
+		out := make([]any, list.Len())
+		for i := range list.Len() {
+			out[i] = valueToAny(r.singular(fd, list.Get(i)))
+		}
+		return slog.AnyValue(out)
+	case fd.IsMap():
+		if v.Map().Len() == 0 {
+			// An empty group would be dropped by most handlers; protojson emits {}.
+			return slog.AnyValue(map[string]any{})
+		}
+		valueFD := fd.MapValue()
+		attrs := make([]slog.Attr, 0, v.Map().Len())
+		v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+			attrs = append(attrs, slog.Attr{Key: k.String(), Value: r.singular(valueFD, mv)})
+			return true
+		})
+		sort.Slice(attrs, func(i, j int) bool { return attrs[i].Key < attrs[j].Key })
+		return slog.GroupValue(attrs...)
+	default:
+		return r.singular(fd, v)
+	}
+}
+
+// singular renders one non-repeated value of fd's kind the way protojson
+// does: enums by name, bytes as base64, 64-bit integers as strings.
+func (r *protoRenderer) singular(fd protoreflect.FieldDescriptor, v protoreflect.Value) slog.Value {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return r.message(v.Message())
+	case protoreflect.EnumKind:
+		return enumValue(fd.Enum(), v.Enum())
+	case protoreflect.BytesKind:
+		return slog.StringValue(base64.StdEncoding.EncodeToString(v.Bytes()))
+	case protoreflect.StringKind:
+		return slog.StringValue(v.String())
+	case protoreflect.BoolKind:
+		return slog.BoolValue(v.Bool())
+	case protoreflect.FloatKind:
+		return floatValue(v.Float(), 32)
+	case protoreflect.DoubleKind:
+		return floatValue(v.Float(), 64)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return slog.Uint64Value(v.Uint())
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return slog.StringValue(strconv.FormatUint(v.Uint(), 10))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return slog.StringValue(strconv.FormatInt(v.Int(), 10))
+	default:
+		return slog.Int64Value(v.Int())
+	}
+}
+
+// enumValue renders enum number n of ed by name, falling back to the number
+// for values the descriptor does not know. NullValue renders as null.
+func enumValue(ed protoreflect.EnumDescriptor, n protoreflect.EnumNumber) slog.Value {
+	if ed.FullName() == "google.protobuf.NullValue" {
+		return slog.AnyValue(nil)
+	}
+	if ev := ed.Values().ByNumber(n); ev != nil {
+		return slog.StringValue(string(ev.Name()))
+	}
+	return slog.Int64Value(int64(n))
+}
+
+// floatValue renders f with the shortest representation at bitSize precision,
+// spelling out the non-finite values JSON cannot hold.
+func floatValue(f float64, bitSize int) slog.Value {
+	switch {
+	case math.IsNaN(f):
+		return slog.StringValue("NaN")
+	case math.IsInf(f, 1):
+		return slog.StringValue("Infinity")
+	case math.IsInf(f, -1):
+		return slog.StringValue("-Infinity")
+	}
+	if bitSize == 32 {
+		f, _ = strconv.ParseFloat(strconv.FormatFloat(f, 'g', -1, 32), 64)
+	}
+	return slog.Float64Value(f)
+}
+
+// valueToAny converts a rendered slog.Value into plain Go values so it can sit
+// inside a slice, where slog groups cannot.
+func valueToAny(v slog.Value) any {
+	if v.Kind() != slog.KindGroup {
+		return v.Any()
+	}
+	group := v.Group()
+	out := make(map[string]any, len(group))
+	for _, a := range group {
+		out[a.Key] = valueToAny(a.Value)
+	}
+	return out
+}
@@ -0,0 +1,219 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// TestProtoPayloadOmitsSensitiveFields verifies debug_redact and custom options are honored throughout a message.
+func TestProtoPayloadOmitsSensitiveFields(t *testing.T) {
+	schema := testSensitiveSchema()
+	account := newTestAccount("ada")
+	fields := account.Descriptor().Fields()
+	account.Set(fields.ByName("referrer"), protoreflect.ValueOfMessage(newTestAccount("bob")))
+	account.Mutable(fields.ByName("linked")).Map().Set(
+		protoreflect.ValueOfString("eve").MapKey(),
+		protoreflect.ValueOfMessage(newTestAccount("eve")),
+	)
+	packed, err := anypb.New(newTestAccount("mallory"))
+	if err != nil {
+		t.Fatalf("anypb.New: %v", err)
+	}
+	account.Set(fields.ByName("extra"), protoreflect.ValueOfMessage(packed.ProtoReflect()))
+
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithSensitiveFieldOption(schema.sensitive), WithSensitiveFieldOption(nil))
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "request received", "grpc.request.content", account)
+
+	content, ok := valueToAny(attrValue(t, rec.records[0], "grpc.request.content")).(map[string]any)
+	if !ok {
+		t.Fatalf("expected payload to render as a group")
+	}
+	assertAccount(t, "top", content, "ada")
+	assertAccount(t, "oneof", content["referrer"], "bob")
+	linked, _ := content["linked"].(map[string]any)
+	assertAccount(t, "map", linked["eve"], "eve")
+	extra, _ := content["extra"].(map[string]any)
+	if extra["@type"] != "type.googleapis.com/slogcpadapter.test.Account" {
+		t.Fatalf("expected Any @type, got %v", extra["@type"])
+	}
+	assertAccount(t, "any", extra, "mallory")
+}
+
+// TestProtoPayloadMasksSensitiveFields verifies mask mode keeps sensitive keys with a placeholder.
+func TestProtoPayloadMasksSensitiveFields(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithSensitiveFieldMode(SensitiveMask))
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "request received", "grpc.request.content", newTestAccount("ada"))
+
+	content, _ := valueToAny(attrValue(t, rec.records[0], "grpc.request.content")).(map[string]any)
+	if content["password"] != RedactedValue {
+		t.Fatalf("expected debug_redact field to be masked, got %v", content["password"])
+	}
+	if content["ssn"] != "078-05-1120" {
+		t.Fatalf("expected unregistered option to be ignored, got %v", content["ssn"])
+	}
+}
+
+// TestProtoRendererCachesPlans verifies per-descriptor plans are built once.
+func TestProtoRendererCachesPlans(t *testing.T) {
+	r := newProtoRenderer(&loggerConfig{})
+	md := testSensitiveSchema().account
+	if r.plan(md) != r.plan(md) {
+		t.Fatalf("expected cached plan to be reused")
+	}
+}
+
+// TestProtoPayloadWellKnownTypes verifies well-known types and enums render as protojson does.
+func TestProtoPayloadWellKnownTypes(t *testing.T) {
+	st, err := structpb.NewStruct(map[string]any{"b": []any{1.0, "x"}, "a": nil})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+	packed, err := anypb.New(durationpb.New(time.Second))
+	if err != nil {
+		t.Fatalf("anypb.New: %v", err)
+	}
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)))
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "msg",
+		"ts", timestamppb.New(time.Date(2025, 1, 2, 3, 4, 5, 500_000_000, time.UTC)),
+		"dur", durationpb.New(1500*time.Millisecond),
+		"mask", &fieldmaskpb.FieldMask{Paths: []string{"user_id", "card.number"}},
+		"struct", st,
+		"wrapped", wrapperspb.Int64(7),
+		"empty", &emptypb.Empty{},
+		"any", packed,
+		"enum", descriptorpb.FieldDescriptorProto_TYPE_STRING,
+	)
+
+	want := map[string]any{
+		"ts":      "2025-01-02T03:04:05.500Z",
+		"dur":     "1.500s",
+		"mask":    "userId,card.number",
+		"struct":  map[string]any{"a": nil, "b": []any{1.0, "x"}},
+		"wrapped": "7",
+		"empty":   map[string]any{},
+		"any":     map[string]any{"@type": "type.googleapis.com/google.protobuf.Duration", "value": "1s"},
+		"enum":    "TYPE_STRING",
+	}
+	for key, expected := range want {
+		if got := valueToAny(attrValue(t, rec.records[0], key)); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("%s: expected %#v, got %#v", key, expected, got)
+		}
+	}
+}
+
+// TestProtoPayloadScalarEncoding verifies 64-bit integers and floats follow protojson.
+func TestProtoPayloadScalarEncoding(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)))
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "msg",
+		"customer", newTestCustomer(t),
+		"f32", wrapperspb.Float(0.1),
+		"nan", wrapperspb.Double(math.NaN()),
+	)
+
+	customer, _ := valueToAny(attrValue(t, rec.records[0], "customer")).(map[string]any)
+	if customer["id"] != "42" {
+		t.Fatalf("expected int64 as a string, got %#v", customer["id"])
+	}
+	if got := valueToAny(attrValue(t, rec.records[0], "f32")); got != 0.1 {
+		t.Fatalf("expected float32 at its own precision, got %#v", got)
+	}
+	if got := valueToAny(attrValue(t, rec.records[0], "nan")); got != "NaN" {
+		t.Fatalf("expected NaN spelled out, got %#v", got)
+	}
+}
+
+// TestProtoPayloadUnpopulatedAndProtoNames verifies the unpopulated and field-name options.
+func TestProtoPayloadUnpopulatedAndProtoNames(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithEmitUnpopulated(), WithProtoFieldNames())
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "msg",
+		"customer", newTestMessage(t, "Customer"),
+		"account", newTestAccount("ada"),
+	)
+
+	customer, _ := valueToAny(attrValue(t, rec.records[0], "customer")).(map[string]any)
+	want := map[string]any{"email": "", "name": "", "card": nil, "tags": []any{}, "attrs": map[string]any{}, "id": "0", "display_name": ""}
+	if !reflect.DeepEqual(customer, want) {
+		t.Fatalf("expected unpopulated fields %#v, got %#v", want, customer)
+	}
+	account, _ := valueToAny(attrValue(t, rec.records[0], "account")).(map[string]any)
+	for _, key := range []string{"phone", "referrer", "password"} {
+		if _, ok := account[key]; ok {
+			t.Fatalf("expected %s to stay out of the payload", key)
+		}
+	}
+	if _, ok := account["extra"]; !ok {
+		t.Fatalf("expected unpopulated message field as null")
+	}
+}
+
+// assertAccount checks a rendered Account for its user and the absence of sensitive fields.
+func assertAccount(t *testing.T, name string, v any, user string) {
+	t.Helper()
+	m, ok := v.(map[string]any)
+	if !ok {
+		t.Fatalf("%s: expected rendered account, got %T", name, v)
+	}
+	if m["user"] != user {
+		t.Fatalf("%s: expected user %q, got %v", name, user, m["user"])
+	}
+	for _, key := range []string{"password", "ssn"} {
+		if _, ok := m[key]; ok {
+			t.Fatalf("%s: expected %s to be omitted", name, key)
+		}
+	}
+}
+
+// TestProtoPayloadEmptyMessages verifies messages without populated fields
+// are written as empty objects instead of empty groups handlers drop.
+func TestProtoPayloadEmptyMessages(t *testing.T) {
+	customer := newTestMessage(t, "Customer")
+	customer.Mutable(customer.Descriptor().Fields().ByName("card"))
+	var buf bytes.Buffer
+	logger := NewLogger(nil, WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "request received", "grpc.request.content", newTestMessage(t, "Customer"))
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "response sent", "grpc.response.content", customer)
+
+	lines := parseLines(t, &buf)
+	if got, ok := lines[0]["grpc.request.content"]; !ok || !reflect.DeepEqual(got, map[string]any{}) {
+		t.Fatalf("expected an empty request object, got %#v", got)
+	}
+	want := map[string]any{"card": map[string]any{}}
+	if got := lines[1]["grpc.response.content"]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+}
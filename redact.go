@@ -0,0 +1,544 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// RedactionMode selects what a [RedactionRule] does with a matching value.
+type RedactionMode int
+
+const (
+	// RedactMask replaces the value with [RedactedValue].
+	RedactMask RedactionMode = iota
+	// RedactDrop removes the attribute, or clears the proto field.
+	RedactDrop
+	// RedactHash replaces the value with a keyed HMAC-SHA256 digest so equal
+	// inputs can still be correlated without revealing them.
+	RedactHash
+)
+
+// RedactedValue is the replacement written by [RedactMask].
+const RedactedValue = "[REDACTED]"
+
+// Common value patterns for [RedactionRule.ValuePattern].
+const (
+	// EmailPattern matches email addresses.
+	EmailPattern = `[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`
+	// CardNumberPattern matches 13 to 19 digit payment card numbers, optionally
+	// separated by spaces or dashes.
+	CardNumberPattern = `\b(?:\d[ \-]?){12,18}\d\b`
+	// BearerTokenPattern matches HTTP bearer credentials.
+	BearerTokenPattern = `(?i)bearer\s+[A-Za-z0-9\-._~+/]+=*`
+)
+
+// RedactionRule describes one class of sensitive values. Exactly one of Key,
+// KeyGlob, ValuePattern or FieldPath must be set.
+type RedactionRule struct {
+	// Name identifies the rule in [Redactor.Counts]. It defaults to the matcher.
+	Name string
+	// Key matches attribute keys, proto field names and string map keys,
+	// case-insensitively, at any depth.
+	Key string
+	// KeyGlob is like Key but uses [path.Match] syntax, for example "x-*-token".
+	KeyGlob string
+	// ValuePattern is a regular expression applied to string values. Matching
+	// substrings are masked or hashed; RedactDrop removes the whole value.
+	ValuePattern string
+	// FieldPath is a dotted proto field path inside request and response
+	// payloads, for example "customer.email".
+	FieldPath string
+	// Mode selects what happens to matching values.
+	Mode RedactionMode
+}
+
+// Redactor applies [RedactionRule]s to interceptor fields and payload messages.
+// It is safe for concurrent use and is installed with [WithRedactor].
+type Redactor struct {
+	rules []compiledRule
+	key   []byte
+}
+
+type compiledRule struct {
+	name      string
+	key       string
+	glob      string
+	pattern   *regexp.Regexp
+	fieldPath string
+	mode      RedactionMode
+	count     atomic.Uint64
+}
+
+// NewRedactor compiles rules into a [Redactor]. Rules are tried in order and the
+// first match wins. hmacKey is required when any rule uses [RedactHash].
+//
+// Example:
+//
+//	redactor, err := slogcpadapter.NewRedactor(hmacKey,
+//		slogcpadapter.RedactionRule{Key: "authorization", Mode: slogcpadapter.RedactDrop},
+//		slogcpadapter.RedactionRule{Name: "email", ValuePattern: slogcpadapter.EmailPattern, Mode: slogcpadapter.RedactHash},
+//		slogcpadapter.RedactionRule{FieldPath: "card.number"},
+//	)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	adapter := slogcpadapter.NewLogger(handler, slogcpadapter.WithRedactor(redactor))
+func NewRedactor(hmacKey []byte, rules ...RedactionRule) (*Redactor, error) {
+	r := &Redactor{rules: make([]compiledRule, len(rules)), key: hmacKey}
+	for i, rule := range rules {
+		if err := r.rules[i].compile(rule, len(hmacKey) > 0); err != nil {
+			return nil, fmt.Errorf("slogcpadapter: redaction rule %d: %w", i, err)
+		}
+	}
+	return r, nil
+}
+
+// compile validates rule and stores its matcher in c.
+func (c *compiledRule) compile(rule RedactionRule, haveKey bool) error {
+	if rule.Mode == RedactHash && !haveKey {
+		return errors.New("hash mode requires an HMAC key")
+	}
+	c.mode = rule.Mode
+	set := 0
+	for _, m := range []string{rule.Key, rule.KeyGlob, rule.ValuePattern, rule.FieldPath} {
+		if m != "" {
+			set++
+			c.name = m
+		}
+	}
+	if set != 1 {
+		return errors.New("exactly one of Key, KeyGlob, ValuePattern or FieldPath must be set")
+	}
+	if rule.Name != "" {
+		c.name = rule.Name
+	}
+	c.key = strings.ToLower(rule.Key)
+	c.glob = strings.ToLower(rule.KeyGlob)
+	c.fieldPath = rule.FieldPath
+	if c.glob != "" {
+		if _, err := path.Match(c.glob, ""); err != nil {
+			return fmt.Errorf("invalid key glob %q: %w", rule.KeyGlob, err)
+		}
+	}
+	if rule.ValuePattern != "" {
+		re, err := regexp.Compile(rule.ValuePattern)
+		if err != nil {
+			return fmt.Errorf("invalid value pattern: %w", err)
+		}
+		c.pattern = re
+	}
+	return nil
+}
+
+// matchesKey reports whether c is a key rule matching key.
+func (c *compiledRule) matchesKey(key string) bool {
+	switch {
+	case c.key != "":
+		return strings.EqualFold(c.key, key)
+	case c.glob != "":
+		ok, _ := path.Match(c.glob, strings.ToLower(key))
+		return ok
+	default:
+		return false
+	}
+}
+
+// WithRedactor makes [NewLogger] apply r to every entry before it is logged.
+// A nil redactor is ignored.
+func WithRedactor(r *Redactor) LoggerOption {
+	return func(cfg *loggerConfig) {
+		if r != nil {
+			cfg.redactor = r
+		}
+	}
+}
+
+// Counts returns how many values each rule has redacted, keyed by rule name.
+func (r *Redactor) Counts() map[string]uint64 {
+	out := make(map[string]uint64, len(r.rules))
+	for i := range r.rules {
+		out[r.rules[i].name] += r.rules[i].count.Load()
+	}
+	return out
+}
+
+// keyRule returns the first key rule matching any of keys, or nil.
+func (r *Redactor) keyRule(keys ...string) *compiledRule {
+	for i := range r.rules {
+		for _, k := range keys {
+			if r.rules[i].matchesKey(k) {
+				return &r.rules[i]
+			}
+		}
+	}
+	return nil
+}
+
+// pathRule returns the first field path rule matching fieldPath, or nil.
+func (r *Redactor) pathRule(fieldPath string) *compiledRule {
+	for i := range r.rules {
+		if r.rules[i].fieldPath != "" && r.rules[i].fieldPath == fieldPath {
+			return &r.rules[i]
+		}
+	}
+	return nil
+}
+
+// redactString applies value pattern rules to s. It reports false when a
+// RedactDrop rule matched and the value should be removed.
+func (r *Redactor) redactString(s string) (string, bool) {
+	for i := range r.rules {
+		c := &r.rules[i]
+		if c.pattern == nil || !c.pattern.MatchString(s) {
+			continue
+		}
+		c.count.Add(1)
+		switch c.mode {
+		case RedactDrop:
+			return "", false
+		case RedactHash:
+			s = c.pattern.ReplaceAllStringFunc(s, r.hash)
+		default:
+			s = c.pattern.ReplaceAllLiteralString(s, RedactedValue)
+		}
+	}
+	return s, true
+}
+
+// hash returns the keyed digest of s.
+func (r *Redactor) hash(s string) string {
+	return hmacDigest(r.key, s)
+}
+
+// hmacDigest returns a stable pseudonym for s under key.
+func hmacDigest(key []byte, s string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(s))
+	return "hmac:" + hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// redact applies the Logger's redactor, if any, to attrs.
+func (l *Logger) redact(attrs []slog.Attr) []slog.Attr {
+	if l.redactor == nil {
+		return attrs
+	}
+	return l.redactor.redactAttrs(attrs)
+}
+
+// redactAttrs applies r to attrs, returning the surviving attributes.
+func (r *Redactor) redactAttrs(attrs []slog.Attr) []slog.Attr {
+	out := attrs[:0]
+	for _, a := range attrs {
+		if a, ok := r.redactAttr(a); ok {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// redactAttr applies r to a single attribute. It reports false when the
+// attribute should be dropped.
+func (r *Redactor) redactAttr(a slog.Attr) (slog.Attr, bool) {
+	if rule := r.keyRule(a.Key); rule != nil {
+		return r.applyToAttr(rule, a)
+	}
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindGroup:
+		group := r.redactAttrs(append([]slog.Attr(nil), v.Group()...))
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(group...)}, true
+	case slog.KindString:
+		s, ok := r.redactString(v.String())
+		return slog.String(a.Key, s), ok
+	case slog.KindAny:
+		if msg, ok := v.Any().(proto.Message); ok && msg != nil {
+			return slog.Any(a.Key, r.redactMessage(msg)), true
+		}
+		x, ok := r.redactValue(v.Any())
+		return slog.Any(a.Key, x), ok
+	}
+	return slog.Attr{Key: a.Key, Value: v}, true
+}
+
+// redactValue applies r to the strings in x when x is a string, a slice of
+// strings or of arbitrary values, or a string-keyed map of strings or of
+// arbitrary values, descending through nested slices and maps. Key rules
+// apply to map keys. An error whose message a rule changes is replaced by the
+// redacted message. Other values, such as structs, are returned as they are.
+// It reports false when x should be dropped.
+func (r *Redactor) redactValue(x any) (any, bool) {
+	switch y := x.(type) {
+	case string:
+		return r.redactString(y)
+	case []string:
+		out := make([]string, 0, len(y))
+		for _, s := range y {
+			if s, ok := r.redactString(s); ok {
+				out = append(out, s)
+			}
+		}
+		return out, true
+	case []any:
+		out := make([]any, 0, len(y))
+		for _, e := range y {
+			if e, ok := r.redactValue(e); ok {
+				out = append(out, e)
+			}
+		}
+		return out, true
+	case error:
+		msg := y.Error()
+		if s, ok := r.redactString(msg); !ok || s != msg {
+			return s, ok
+		}
+		return x, true
+	case []map[string]string:
+		out := make([]map[string]string, len(y))
+		for i, m := range y {
+			v, _ := r.redactValue(m)
+			out[i], _ = v.(map[string]string)
+		}
+		return out, true
+	case map[string]string:
+		out := make(map[string]string, len(y))
+		for k, v := range y {
+			if v, ok := r.redactEntry(k, v); ok {
+				out[k], _ = v.(string)
+			}
+		}
+		return out, true
+	case map[string]any:
+		out := make(map[string]any, len(y))
+		for k, v := range y {
+			if v, ok := r.redactEntry(k, v); ok {
+				out[k] = v
+			}
+		}
+		return out, true
+	default:
+		return x, true
+	}
+}
+
+// redactEntry applies key rules to the map key k, or r to v when none
+// matches. It reports false when the entry should be dropped.
+func (r *Redactor) redactEntry(k string, v any) (any, bool) {
+	rule := r.keyRule(k)
+	if rule == nil {
+		return r.redactValue(v)
+	}
+	rule.count.Add(1)
+	if rule.mode == RedactDrop {
+		return nil, false
+	}
+	return r.replacement(rule, fmt.Sprint(v)), true
+}
+
+// applyToAttr applies rule to the whole value of a.
+func (r *Redactor) applyToAttr(rule *compiledRule, a slog.Attr) (slog.Attr, bool) {
+	rule.count.Add(1)
+	switch rule.mode {
+	case RedactDrop:
+		return a, false
+	case RedactHash:
+		return slog.String(a.Key, r.hash(a.Value.Resolve().String())), true
+	default:
+		return slog.String(a.Key, RedactedValue), true
+	}
+}
+
+// redactMessage returns a redacted copy of msg, leaving msg untouched.
+func (r *Redactor) redactMessage(msg proto.Message) proto.Message {
+	clone := proto.Clone(msg)
+	r.redactReflect(clone.ProtoReflect(), "")
+	return clone
+}
+
+// redactReflect applies r to m in place. prefix is the dotted field path of m.
+func (r *Redactor) redactReflect(m protoreflect.Message, prefix string) {
+	if m.Descriptor().FullName() == "google.protobuf.Any" {
+		r.redactAny(m, prefix)
+		return
+	}
+	type field struct {
+		fd   protoreflect.FieldDescriptor
+		path string
+	}
+	var fields []field
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, field{fd: fd, path: joinPath(prefix, string(fd.Name()))})
+		return true
+	})
+	for _, f := range fields {
+		if rule := r.fieldRule(f.fd, f.path); rule != nil {
+			r.applyToField(rule, m, f.fd)
+			continue
+		}
+		r.redactFieldValues(m, f.fd, f.path)
+	}
+}
+
+// fieldRule returns the path or key rule matching fd at fieldPath, or nil.
+func (r *Redactor) fieldRule(fd protoreflect.FieldDescriptor, fieldPath string) *compiledRule {
+	if rule := r.pathRule(fieldPath); rule != nil {
+		return rule
+	}
+	return r.keyRule(string(fd.Name()), fd.JSONName())
+}
+
+// redactAny unpacks an Any payload, redacts it and packs it back.
+// Payloads of unknown types are left as they are.
+func (r *Redactor) redactAny(m protoreflect.Message, prefix string) {
+	anyMsg, ok := m.Interface().(*anypb.Any)
+	if !ok {
+		return
+	}
+	inner, err := anyMsg.UnmarshalNew()
+	if err != nil {
+		return
+	}
+	r.redactReflect(inner.ProtoReflect(), prefix)
+	if packed, err := anypb.New(inner); err == nil {
+		anyMsg.Value = packed.Value
+	}
+}
+
+// redactFieldValues descends into fd's value when no rule matched fd itself.
+func (r *Redactor) redactFieldValues(m protoreflect.Message, fd protoreflect.FieldDescriptor, fieldPath string) {
+	switch {
+	case fd.IsList():
+		r.redactList(m.Mutable(fd).List(), fd, fieldPath)
+	case fd.IsMap():
+		r.redactMap(m.Mutable(fd).Map(), fd.MapValue(), fieldPath)
+	case fd.Message() != nil:
+		r.redactReflect(m.Mutable(fd).Message(), fieldPath)
+	case fd.Kind() == protoreflect.StringKind:
+		if s, ok := r.redactString(m.Get(fd).String()); ok {
+			m.Set(fd, protoreflect.ValueOfString(s))
+		} else {
+			m.Clear(fd)
+		}
+	}
+}
+
+// redactList applies r to each element of list.
+func (r *Redactor) redactList(list protoreflect.List, fd protoreflect.FieldDescriptor, fieldPath string) {
+	for i := 0; i < list.Len(); i++ {
+		switch {
+		case fd.Message() != nil:
+			r.redactReflect(list.Get(i).Message(), fieldPath)
+		case fd.Kind() == protoreflect.StringKind:
+			s, ok := r.redactString(list.Get(i).String())
+			if !ok {
+				s = ""
+			}
+			list.Set(i, protoreflect.ValueOfString(s))
+		}
+	}
+}
+
+// redactMap applies key rules to string map keys and r to each map value.
+func (r *Redactor) redactMap(mp protoreflect.Map, valueFD protoreflect.FieldDescriptor, fieldPath string) {
+	var drop []protoreflect.MapKey
+	mp.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+		if rule := r.keyRule(k.String()); rule != nil {
+			rule.count.Add(1)
+			if rule.mode == RedactDrop || valueFD.Kind() != protoreflect.StringKind {
+				drop = append(drop, k)
+			} else {
+				mp.Set(k, protoreflect.ValueOfString(r.replacement(rule, v.String())))
+			}
+			return true
+		}
+		switch {
+		case valueFD.Message() != nil:
+			r.redactReflect(v.Message(), fieldPath)
+		case valueFD.Kind() == protoreflect.StringKind:
+			if s, ok := r.redactString(v.String()); ok {
+				mp.Set(k, protoreflect.ValueOfString(s))
+			} else {
+				drop = append(drop, k)
+			}
+		}
+		return true
+	})
+	for _, k := range drop {
+		mp.Clear(k)
+	}
+}
+
+// applyToField applies rule to the whole of fd in m.
+// Mask and hash rewrite string and bytes fields and clear everything else.
+func (r *Redactor) applyToField(rule *compiledRule, m protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	rule.count.Add(1)
+	if rule.mode == RedactDrop || fd.IsMap() || fd.Message() != nil {
+		m.Clear(fd)
+		return
+	}
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		r.rewriteStrings(rule, m, fd)
+	case protoreflect.BytesKind:
+		if fd.IsList() {
+			m.Clear(fd)
+			return
+		}
+		m.Set(fd, protoreflect.ValueOfBytes([]byte(r.replacement(rule, string(m.Get(fd).Bytes())))))
+	default:
+		m.Clear(fd)
+	}
+}
+
+// rewriteStrings replaces a singular or repeated string field with its redacted form.
+func (r *Redactor) rewriteStrings(rule *compiledRule, m protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	if !fd.IsList() {
+		m.Set(fd, protoreflect.ValueOfString(r.replacement(rule, m.Get(fd).String())))
+		return
+	}
+	list := m.Mutable(fd).List()
+	for i := 0; i < list.Len(); i++ {
+		list.Set(i, protoreflect.ValueOfString(r.replacement(rule, list.Get(i).String())))
+	}
+}
+
+// replacement returns the masked or hashed form of s for rule.
+func (r *Redactor) replacement(rule *compiledRule, s string) string {
+	if rule.mode == RedactHash {
+		return r.hash(s)
+	}
+	return RedactedValue
+}
+
+// joinPath appends name to a dotted field path.
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,201 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// TestNewRedactorValidatesRules verifies malformed rules are rejected at construction.
+func TestNewRedactorValidatesRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule RedactionRule
+	}{
+		{"no-matcher", RedactionRule{}},
+		{"two-matchers", RedactionRule{Key: "a", KeyGlob: "b*"}},
+		{"bad-regex", RedactionRule{ValuePattern: "("}},
+		{"bad-glob", RedactionRule{KeyGlob: "["}},
+		{"hash-without-key", RedactionRule{Key: "token", Mode: RedactHash}},
+	}
+	for _, tt := range tests {
+		if _, err := NewRedactor(nil, tt.rule); err == nil {
+			t.Fatalf("%s: expected error", tt.name)
+		}
+	}
+}
+
+// TestRedactorAppliesToFields verifies key, glob and value rules on interceptor fields.
+func TestRedactorAppliesToFields(t *testing.T) {
+	redactor, err := NewRedactor([]byte("k"),
+		RedactionRule{Key: "authorization", Mode: RedactDrop},
+		RedactionRule{KeyGlob: "x-*-token", Mode: RedactMask},
+		RedactionRule{Name: "email", ValuePattern: EmailPattern, Mode: RedactHash},
+		RedactionRule{Name: "bearer", ValuePattern: BearerTokenPattern},
+	)
+	if err != nil {
+		t.Fatalf("NewRedactor: %v", err)
+	}
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithRedactor(redactor), WithRedactor(nil))
+
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "msg",
+		"Authorization", "Bearer abc",
+		"x-goog-token", "secret",
+		"user", "ada@example.com",
+		"note", "sent Bearer xyz.123 to upstream",
+		"meta", slog.GroupValue(slog.String("x-api-token", "nested")),
+		"ok", "plain",
+	)
+
+	attrs := collectAttrs(rec.records[0])
+	if _, ok := attrs["Authorization"]; ok {
+		t.Fatalf("expected authorization to be dropped")
+	}
+	if attrs["x-goog-token"] != RedactedValue {
+		t.Fatalf("expected glob match to be masked, got %v", attrs["x-goog-token"])
+	}
+	if user, _ := attrs["user"].(string); !strings.HasPrefix(user, "hmac:") {
+		t.Fatalf("expected email to be hashed, got %v", attrs["user"])
+	}
+	if attrs["note"] != "sent "+RedactedValue+" to upstream" {
+		t.Fatalf("expected bearer token to be masked in place, got %v", attrs["note"])
+	}
+	if attrs["ok"] != "plain" {
+		t.Fatalf("expected unrelated value to survive, got %v", attrs["ok"])
+	}
+	meta, _ := attrs["meta"].([]slog.Attr)
+	if len(meta) != 1 || meta[0].Value.String() != RedactedValue {
+		t.Fatalf("expected nested group value to be masked, got %v", meta)
+	}
+
+	counts := redactor.Counts()
+	for _, name := range []string{"authorization", "x-*-token", "email", "bearer"} {
+		if counts[name] == 0 {
+			t.Fatalf("expected rule %q to be counted, got %v", name, counts)
+		}
+	}
+}
+
+// TestRedactorAppliesToSlicesAndMaps verifies strings inside slices and maps are redacted.
+func TestRedactorAppliesToSlicesAndMaps(t *testing.T) {
+	redactor, err := NewRedactor(nil,
+		RedactionRule{Key: "token", Mode: RedactDrop},
+		RedactionRule{Name: "email", ValuePattern: EmailPattern},
+	)
+	if err != nil {
+		t.Fatalf("NewRedactor: %v", err)
+	}
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithRedactor(redactor))
+
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "msg",
+		"emails", []string{"a@example.com", "plain"},
+		"contact", map[string]string{"email": "b@example.com", "token": "t"},
+		"nested", map[string]any{"list": []any{"c@example.com", 3}},
+	)
+
+	attrs := collectAttrs(rec.records[0])
+	if got, want := attrs["emails"], []string{RedactedValue, "plain"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got, want := attrs["contact"], map[string]string{"email": RedactedValue}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got, want := attrs["nested"], map[string]any{"list": []any{RedactedValue, 3}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+// TestRedactorAppliesToProtoPayloads verifies path, key and value rules inside payload messages.
+func TestRedactorAppliesToProtoPayloads(t *testing.T) {
+	redactor, err := NewRedactor(nil,
+		RedactionRule{FieldPath: "card.number"},
+		RedactionRule{Key: "session_token", Mode: RedactDrop},
+		RedactionRule{Key: "name", Mode: RedactDrop},
+		RedactionRule{Name: "email", ValuePattern: EmailPattern},
+	)
+	if err != nil {
+		t.Fatalf("NewRedactor: %v", err)
+	}
+	customer := newTestCustomer(t)
+	original := proto.Clone(customer)
+
+	redacted := redactor.redactMessage(customer).ProtoReflect()
+	if !proto.Equal(customer, original) {
+		t.Fatalf("expected original message to be untouched")
+	}
+	if got := getString(redacted, "card", "number"); got != RedactedValue {
+		t.Fatalf("expected card number masked, got %q", got)
+	}
+	if got := getString(redacted, "email"); got != RedactedValue {
+		t.Fatalf("expected email masked, got %q", got)
+	}
+	fields := redacted.Descriptor().Fields()
+	if redacted.Has(fields.ByName("name")) {
+		t.Fatalf("expected name to be cleared")
+	}
+	if got := redacted.Get(fields.ByName("tags")).List().Get(1).String(); got != "contact "+RedactedValue {
+		t.Fatalf("expected repeated string to be masked, got %q", got)
+	}
+	attrs := redacted.Get(fields.ByName("attrs")).Map()
+	if attrs.Has(protoreflect.ValueOfString("session_token").MapKey()) {
+		t.Fatalf("expected session_token map entry to be dropped")
+	}
+	if got := attrs.Get(protoreflect.ValueOfString("region").MapKey()).String(); got != "eu" {
+		t.Fatalf("expected region to survive, got %q", got)
+	}
+}
+
+// TestRedactorUnpacksAny verifies payloads packed in Any are redacted too.
+func TestRedactorUnpacksAny(t *testing.T) {
+	redactor, err := NewRedactor(nil, RedactionRule{Key: "password", Mode: RedactDrop})
+	if err != nil {
+		t.Fatalf("NewRedactor: %v", err)
+	}
+	inner, err := structpb.NewStruct(map[string]any{"user": "ada", "password": "hunter2"})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+	packed, err := anypb.New(inner)
+	if err != nil {
+		t.Fatalf("anypb.New: %v", err)
+	}
+
+	out, ok := redactor.redactMessage(packed).(*anypb.Any)
+	if !ok {
+		t.Fatalf("expected *anypb.Any")
+	}
+	var got structpb.Struct
+	if err := out.UnmarshalTo(&got); err != nil {
+		t.Fatalf("UnmarshalTo: %v", err)
+	}
+	if _, ok := got.Fields["password"]; ok {
+		t.Fatalf("expected password to be dropped from Any payload")
+	}
+	if got.Fields["user"].GetStringValue() != "ada" {
+		t.Fatalf("expected user to survive")
+	}
+}
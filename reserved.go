@@ -0,0 +1,141 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"log/slog"
+
+	"github.com/pjscruggs/slogcp"
+)
+
+// ReservedKeys lists the top-level keys that slogcp writes itself or that
+// Cloud Logging interprets, and that middleware fields therefore must not use.
+var ReservedKeys = []string{
+	"severity",
+	"message",
+	"time",
+	"httpRequest",
+	"stack_trace",
+	"error_type",
+	"serviceContext",
+	slogcp.TraceKey,
+	slogcp.SpanKey,
+	slogcp.SampledKey,
+	slogcp.LabelsGroup,
+	"logging.googleapis.com/sourceLocation",
+	"logging.googleapis.com/insertId",
+	"logging.googleapis.com/operation",
+	"otel.trace_id",
+	"otel.span_id",
+	"otel.trace_sampled",
+}
+
+// ReservedFieldsKey is the group that holds colliding fields under [ReservedKeyNest].
+const ReservedFieldsKey = "fields"
+
+// DefaultReservedKeyPrefix is the prefix [ReservedKeyRename] adds by default.
+const DefaultReservedKeyPrefix = "field."
+
+// ReservedKeyPolicy selects what happens to a middleware field named after one
+// of the [ReservedKeys].
+type ReservedKeyPolicy int
+
+const (
+	// ReservedKeyRename prefixes the field's key, by default with
+	// [DefaultReservedKeyPrefix], so "severity" is logged as "field.severity".
+	ReservedKeyRename ReservedKeyPolicy = iota
+	// ReservedKeyNest moves the field into the [ReservedFieldsKey] group.
+	ReservedKeyNest
+	// ReservedKeyDrop drops the field and counts it; see
+	// [Logger.DroppedReservedFields].
+	ReservedKeyDrop
+)
+
+var reservedKeys = func() map[string]bool {
+	m := make(map[string]bool, len(ReservedKeys))
+	for _, k := range ReservedKeys {
+		m[k] = true
+	}
+	return m
+}()
+
+// WithReservedKeyPolicy makes [Logger.Log] apply policy to middleware and
+// injected fields whose keys collide with [ReservedKeys]. The default is
+// [ReservedKeyRename]. Attributes the adapter adds itself are not affected.
+func WithReservedKeyPolicy(policy ReservedKeyPolicy) LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.reservedPolicy = policy
+	}
+}
+
+// WithReservedKeyPrefix sets the prefix [ReservedKeyRename] adds to colliding
+// keys. An empty prefix is ignored.
+func WithReservedKeyPrefix(prefix string) LoggerOption {
+	return func(cfg *loggerConfig) {
+		if prefix != "" {
+			cfg.reservedPrefix = prefix
+		}
+	}
+}
+
+// DroppedReservedFields returns how many fields [ReservedKeyDrop] has dropped.
+func (l *Logger) DroppedReservedFields() uint64 {
+	if l == nil {
+		return 0
+	}
+	return l.reservedDrops.Load()
+}
+
+// isReserved reports whether a collides with a reserved key. A group under
+// slogcp.LabelsGroup and a LogValuer under httpRequest, such as a
+// *slogcp.HTTPRequest, are what slogcp expects there and are kept.
+func isReserved(a slog.Attr) bool {
+	if !reservedKeys[a.Key] {
+		return false
+	}
+	switch a.Key {
+	case slogcp.LabelsGroup:
+		return a.Value.Kind() != slog.KindGroup
+	case "httpRequest":
+		return a.Value.Kind() != slog.KindLogValuer
+	default:
+		return true
+	}
+}
+
+// protectReserved applies the reserved key policy to attrs.
+func (l *Logger) protectReserved(attrs []slog.Attr) []slog.Attr {
+	var nested []slog.Attr
+	kept := attrs[:0]
+	for _, a := range attrs {
+		if !isReserved(a) {
+			kept = append(kept, a)
+			continue
+		}
+		switch l.reservedPolicy {
+		case ReservedKeyNest:
+			nested = append(nested, a)
+		case ReservedKeyDrop:
+			l.reservedDrops.Add(1)
+		default:
+			a.Key = l.reservedPrefix + a.Key
+			kept = append(kept, a)
+		}
+	}
+	if len(nested) > 0 {
+		kept = append(kept, slog.Attr{Key: ReservedFieldsKey, Value: slog.GroupValue(nested...)})
+	}
+	return kept
+}
@@ -0,0 +1,110 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/pjscruggs/slogcp"
+)
+
+// TestReservedKeyPolicies checks every reserved key against every policy.
+func TestReservedKeyPolicies(t *testing.T) {
+	policies := []struct {
+		name string
+		opts []LoggerOption
+		want func(key string) map[string]any
+	}{
+		{
+			name: "rename",
+			want: func(key string) map[string]any {
+				return map[string]any{"grpc.service": "pkg.Svc", "field." + key: "collide"}
+			},
+		},
+		{
+			name: "rename with prefix",
+			opts: []LoggerOption{WithReservedKeyPrefix("mw_")},
+			want: func(key string) map[string]any {
+				return map[string]any{"grpc.service": "pkg.Svc", "mw_" + key: "collide"}
+			},
+		},
+		{
+			name: "nest",
+			opts: []LoggerOption{WithReservedKeyPolicy(ReservedKeyNest)},
+			want: func(key string) map[string]any {
+				return map[string]any{"grpc.service": "pkg.Svc", ReservedFieldsKey: map[string]any{key: "collide"}}
+			},
+		},
+		{
+			name: "drop",
+			opts: []LoggerOption{WithReservedKeyPolicy(ReservedKeyDrop)},
+			want: func(string) map[string]any {
+				return map[string]any{"grpc.service": "pkg.Svc"}
+			},
+		},
+	}
+	for _, policy := range policies {
+		for _, key := range ReservedKeys {
+			t.Run(policy.name+"/"+key, func(t *testing.T) {
+				rec := &recordingHandler{}
+				logger := NewLogger(nil, append([]LoggerOption{WithLogger(slog.New(rec))}, policy.opts...)...)
+
+				logger.Log(context.Background(), grpc_logging.LevelInfo, "finished call", "grpc.service", "pkg.Svc", key, "collide")
+				got := make(map[string]any)
+				rec.records[0].Attrs(func(a slog.Attr) bool {
+					got[a.Key] = valueToAny(a.Value)
+					return true
+				})
+				if want := policy.want(key); !reflect.DeepEqual(got, want) {
+					t.Fatalf("expected %v, got %v", want, got)
+				}
+			})
+		}
+	}
+}
+
+// TestReservedKeyDropCounter verifies dropped fields are counted.
+func TestReservedKeyDropCounter(t *testing.T) {
+	logger := NewLogger(nil, WithLogger(slog.New(&recordingHandler{})), WithReservedKeyPolicy(ReservedKeyDrop))
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "msg", "severity", "LOUD", "message", "other", "ok", true)
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "msg", "time", "now")
+	if got := logger.DroppedReservedFields(); got != 3 {
+		t.Fatalf("expected 3 dropped fields, got %d", got)
+	}
+}
+
+// TestReservedKeysKeepExpectedShapes verifies labels groups and HTTP requests
+// pass through, since slogcp expects them under those keys.
+func TestReservedKeysKeepExpectedShapes(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)))
+	req := &slogcp.HTTPRequest{RequestMethod: "GET"}
+
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "msg",
+		slog.Group(slogcp.LabelsGroup, slog.String("tenant", "t-1")),
+		"httpRequest", req,
+	)
+	attrs := collectAttrs(rec.records[0])
+	if _, ok := attrs[slogcp.LabelsGroup]; !ok {
+		t.Fatalf("expected the labels group to be kept, got %v", attrs)
+	}
+	if attrs["httpRequest"] != req {
+		t.Fatalf("expected the HTTP request to be kept, got %v", attrs)
+	}
+}
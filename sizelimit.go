@@ -0,0 +1,337 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/pjscruggs/slogcp"
+)
+
+// TruncatedFieldsKey is the attribute listing the dotted paths of values the
+// size governor shortened or replaced.
+const TruncatedFieldsKey = "truncated_fields"
+
+// truncatedGroupKey is the key of the marker appended to shortened groups.
+const truncatedGroupKey = "…"
+
+// SizeLimits bounds the size of entries written by the adapter. Cloud Logging
+// rejects entries over 256 KB, so oversized payload logs would otherwise be
+// dropped. A zero field disables that limit.
+type SizeLimits struct {
+	// MaxStringBytes caps string and byte-slice values, including those in
+	// slices and maps. Longer values keep
+	// their first MaxStringBytes bytes followed by "…truncated(N bytes)".
+	MaxStringBytes int
+	// MaxGroupAttrs caps the attributes kept in each group, such as a rendered
+	// payload message, and the elements kept in each slice or map, such as a
+	// repeated field. Dropped attributes or elements are summarized by one
+	// marker.
+	MaxGroupAttrs int
+	// MaxEntryBytes caps the estimated encoded size of an entry's attributes.
+	// Payload attributes, then the largest others, are replaced by markers
+	// until the entry fits. Labels, operation, insert ID and chunk attributes
+	// are never replaced.
+	MaxEntryBytes int
+}
+
+// DefaultSizeLimits returns limits that leave headroom under Cloud Logging's
+// 256 KB entry limit for the fields slogcp adds itself.
+func DefaultSizeLimits() SizeLimits {
+	return SizeLimits{MaxStringBytes: 16 << 10, MaxGroupAttrs: 256, MaxEntryBytes: 200 << 10}
+}
+
+// WithSizeLimits makes [NewLogger] enforce limits on every entry. Shortened
+// values are listed under [TruncatedFieldsKey].
+//
+// Example:
+//
+//	adapter := slogcpadapter.NewLogger(handler,
+//		slogcpadapter.WithSizeLimits(slogcpadapter.DefaultSizeLimits()),
+//	)
+func WithSizeLimits(limits SizeLimits) LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.sizeLimits = &limits
+	}
+}
+
+// structuralKeys lists attributes that Cloud Logging or ReassembleChunks
+// interpret. They are never shortened, since a marker in their place would
+// break labels, operation grouping, deduplication or reassembly.
+var structuralKeys = map[string]bool{
+	slogcp.LabelsGroup: true,
+	OperationKey:       true,
+	InsertIDKey:        true,
+	PayloadChunkKey:    true,
+}
+
+// payloadKeys lists the attributes carrying request and response payloads,
+// which capEntry replaces before any other attribute.
+var payloadKeys = map[string]bool{
+	"grpc.request.content":  true,
+	"grpc.response.content": true,
+	RequestFieldsKey:        true,
+	ResponseFieldsKey:       true,
+}
+
+// truncationMarker returns the marker for n removed bytes.
+func truncationMarker(n int) string {
+	return fmt.Sprintf("…truncated(%d bytes)", n)
+}
+
+// sizeGovernor applies SizeLimits to one entry and collects truncated paths.
+type sizeGovernor struct {
+	limits    SizeLimits
+	truncated []string
+}
+
+// limitAttrs enforces limits on attrs for an entry logged with msg.
+func (limits *SizeLimits) limitAttrs(msg string, attrs []slog.Attr) []slog.Attr {
+	if limits == nil {
+		return attrs
+	}
+	g := &sizeGovernor{limits: *limits}
+	for i, a := range attrs {
+		if !structuralKeys[a.Key] {
+			attrs[i].Value = g.value(a.Key, a.Value)
+		}
+	}
+	attrs = g.capEntry(msg, attrs)
+	if len(g.truncated) > 0 {
+		attrs = append(attrs, slog.Any(TruncatedFieldsKey, g.truncated))
+	}
+	return attrs
+}
+
+// value shortens v, found at path, according to the per-value limits.
+func (g *sizeGovernor) value(path string, v slog.Value) slog.Value {
+	v = v.Resolve()
+	switch v.Kind() {
+	case slog.KindString:
+		if s, ok := g.shorten(v.String()); ok {
+			g.truncated = append(g.truncated, path)
+			return slog.StringValue(s)
+		}
+	case slog.KindGroup:
+		return g.group(path, v.Group())
+	case slog.KindAny:
+		if x, ok := g.anyValue(path, v.Any()); ok {
+			return slog.AnyValue(x)
+		}
+	}
+	return v
+}
+
+// anyValue shortens the byte slices and strings in x, found at path, and caps
+// the length of the slices and maps holding them, such as the repeated and
+// map fields of a rendered payload. It reports whether anything changed.
+func (g *sizeGovernor) anyValue(path string, x any) (any, bool) {
+	switch y := x.(type) {
+	case string:
+		if s, ok := g.shorten(y); ok {
+			g.truncated = append(g.truncated, path)
+			return s, true
+		}
+	case []byte:
+		if limit := g.limits.MaxStringBytes; limit > 0 && len(y) > limit {
+			g.truncated = append(g.truncated, path)
+			return base64.StdEncoding.EncodeToString(y[:limit]) + truncationMarker(len(y)-limit), true
+		}
+	case []string:
+		items := make([]any, len(y))
+		for i, s := range y {
+			items[i] = s
+		}
+		return g.list(path, items)
+	case []any:
+		return g.list(path, y)
+	case map[string]string:
+		m := make(map[string]any, len(y))
+		for k, s := range y {
+			m[k] = s
+		}
+		return g.object(path, m)
+	case map[string]any:
+		return g.object(path, y)
+	}
+	return x, false
+}
+
+// keepCount returns how many of n elements MaxGroupAttrs keeps.
+func (g *sizeGovernor) keepCount(n int) int {
+	if limit := g.limits.MaxGroupAttrs; limit > 0 && n > limit {
+		return limit
+	}
+	return n
+}
+
+// list applies the limits to the elements of items, found at path, and caps
+// their count, appending one marker for the dropped elements.
+func (g *sizeGovernor) list(path string, items []any) (any, bool) {
+	keep := g.keepCount(len(items))
+	out := make([]any, keep, keep+1)
+	changed := keep < len(items)
+	for i, item := range items[:keep] {
+		v, ok := g.anyValue(path+"."+strconv.Itoa(i), item)
+		out[i], changed = v, changed || ok
+	}
+	if !changed {
+		return items, false
+	}
+	if keep < len(items) {
+		g.truncated = append(g.truncated, path)
+		out = append(out, truncationMarker(estimateValue(slog.AnyValue(items[keep:]))))
+	}
+	return out, true
+}
+
+// object applies the limits to the values of m, found at path, and caps its
+// size, keeping the first keys in sorted order and adding one marker for the
+// dropped entries.
+func (g *sizeGovernor) object(path string, m map[string]any) (any, bool) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	keep := g.keepCount(len(keys))
+	out := make(map[string]any, keep+1)
+	changed := keep < len(keys)
+	for _, k := range keys[:keep] {
+		v, ok := g.anyValue(path+"."+k, m[k])
+		out[k], changed = v, changed || ok
+	}
+	if !changed {
+		return m, false
+	}
+	if keep < len(keys) {
+		dropped := make(map[string]any, len(keys)-keep)
+		for _, k := range keys[keep:] {
+			dropped[k] = m[k]
+		}
+		g.truncated = append(g.truncated, path)
+		out[truncatedGroupKey] = truncationMarker(estimateValue(slog.AnyValue(dropped)))
+	}
+	return out, true
+}
+
+// shorten cuts s at a rune boundary within MaxStringBytes, reporting whether it did.
+func (g *sizeGovernor) shorten(s string) (string, bool) {
+	limit := g.limits.MaxStringBytes
+	if limit <= 0 || len(s) <= limit {
+		return s, false
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + truncationMarker(len(s)-cut), true
+}
+
+// group applies the limits inside a group and caps its attribute count.
+func (g *sizeGovernor) group(path string, group []slog.Attr) slog.Value {
+	keep := g.keepCount(len(group))
+	out := make([]slog.Attr, keep, keep+1)
+	for i, a := range group[:keep] {
+		out[i] = slog.Attr{Key: a.Key, Value: g.value(path+"."+a.Key, a.Value)}
+	}
+	if keep < len(group) {
+		dropped := 0
+		for _, a := range group[keep:] {
+			dropped += estimateAttr(a)
+		}
+		g.truncated = append(g.truncated, path)
+		out = append(out, slog.String(truncatedGroupKey, truncationMarker(dropped)))
+	}
+	return slog.GroupValue(out...)
+}
+
+// capEntry replaces payload attributes, then the other attributes, largest
+// first, with markers until the estimated size of msg and attrs fits
+// MaxEntryBytes. Structural attributes are kept.
+func (g *sizeGovernor) capEntry(msg string, attrs []slog.Attr) []slog.Attr {
+	limit := g.limits.MaxEntryBytes
+	if limit <= 0 {
+		return attrs
+	}
+	sizes := make([]int, len(attrs))
+	total := len(msg)
+	for i, a := range attrs {
+		sizes[i] = estimateAttr(a)
+		total += sizes[i]
+	}
+	if total <= limit {
+		return attrs
+	}
+	order := make([]int, 0, len(attrs))
+	for i, a := range attrs {
+		if !structuralKeys[a.Key] {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		pa, pb := payloadKeys[attrs[order[a]].Key], payloadKeys[attrs[order[b]].Key]
+		if pa != pb {
+			return pa
+		}
+		return sizes[order[a]] > sizes[order[b]]
+	})
+	for _, i := range order {
+		if total <= limit {
+			break
+		}
+		marker := truncationMarker(sizes[i])
+		total -= sizes[i] - (len(attrs[i].Key) + len(marker) + 4)
+		attrs[i].Value = slog.StringValue(marker)
+		g.truncated = append(g.truncated, attrs[i].Key)
+	}
+	return attrs
+}
+
+// estimateAttr approximates the JSON-encoded size of a, including its key
+// and punctuation.
+func estimateAttr(a slog.Attr) int {
+	return len(a.Key) + 4 + estimateValue(a.Value)
+}
+
+// estimateValue approximates the JSON-encoded size of v.
+func estimateValue(v slog.Value) int {
+	switch v.Kind() {
+	case slog.KindString:
+		return len(v.String()) + 2
+	case slog.KindGroup:
+		n := 2
+		for _, a := range v.Group() {
+			n += estimateAttr(a)
+		}
+		return n
+	case slog.KindAny:
+		encoded, err := json.Marshal(v.Any())
+		if err != nil {
+			return len(fmt.Sprint(v.Any()))
+		}
+		return len(encoded)
+	case slog.KindLogValuer:
+		return estimateValue(v.Resolve())
+	default:
+		return len(v.String())
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/pjscruggs/slogcp"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TestSizeLimitsTruncateValues verifies strings, bytes and groups are shortened with markers.
+func TestSizeLimitsTruncateValues(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithSizeLimits(SizeLimits{MaxStringBytes: 4, MaxGroupAttrs: 2}))
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "msg",
+		"short", "abcd",
+		"long", "abcdefghij",
+		"utf8", "ééé",
+		"blob", []byte("0123456789"),
+		"payload", slog.GroupValue(slog.String("a", "1"), slog.String("b", "xxxxxxxx"), slog.String("c", "3")),
+	)
+
+	attrs := collectAttrs(rec.records[0])
+	if attrs["short"] != "abcd" {
+		t.Fatalf("expected short value untouched, got %v", attrs["short"])
+	}
+	if attrs["long"] != "abcd…truncated(6 bytes)" {
+		t.Fatalf("expected long value truncated, got %v", attrs["long"])
+	}
+	if attrs["utf8"] != "éé…truncated(2 bytes)" {
+		t.Fatalf("expected rune-safe truncation, got %v", attrs["utf8"])
+	}
+	if blob, _ := attrs["blob"].(string); !strings.HasSuffix(blob, "…truncated(6 bytes)") {
+		t.Fatalf("expected byte slice truncated, got %v", attrs["blob"])
+	}
+	payload := valueToAny(attrValue(t, rec.records[0], "payload")).(map[string]any)
+	want := map[string]any{"a": "1", "b": "xxxx…truncated(4 bytes)", truncatedGroupKey: "…truncated(8 bytes)"}
+	if !reflect.DeepEqual(payload, want) {
+		t.Fatalf("expected capped group %v, got %v", want, payload)
+	}
+	wantPaths := []string{"long", "utf8", "blob", "payload.b", "payload"}
+	if got := attrs[TruncatedFieldsKey]; !reflect.DeepEqual(got, wantPaths) {
+		t.Fatalf("expected truncated fields %v, got %v", wantPaths, got)
+	}
+}
+
+// TestSizeLimitsCapEntry verifies the largest attributes are replaced until the entry fits.
+func TestSizeLimitsCapEntry(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithSizeLimits(SizeLimits{MaxEntryBytes: 200}))
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "msg",
+		"grpc.method", "Get",
+		"grpc.request.content", slog.GroupValue(slog.String("data", strings.Repeat("x", 500))),
+		"note", strings.Repeat("y", 100),
+	)
+
+	attrs := collectAttrs(rec.records[0])
+	if content, _ := attrs["grpc.request.content"].(string); !strings.HasPrefix(content, "…truncated(") {
+		t.Fatalf("expected largest attribute replaced, got %v", attrs["grpc.request.content"])
+	}
+	if attrs["note"] != strings.Repeat("y", 100) || attrs["grpc.method"] != "Get" {
+		t.Fatalf("expected smaller attributes kept once the entry fits")
+	}
+	if got := attrs[TruncatedFieldsKey]; !reflect.DeepEqual(got, []string{"grpc.request.content"}) {
+		t.Fatalf("expected truncated fields to name the replaced attribute, got %v", got)
+	}
+}
+
+// TestSizeLimitsDisabledByDefault verifies entries are untouched without the option.
+func TestSizeLimitsDisabledByDefault(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)))
+	long := strings.Repeat("z", 300<<10)
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "msg", "big", long)
+
+	attrs := collectAttrs(rec.records[0])
+	if attrs["big"] != long {
+		t.Fatalf("expected value untouched")
+	}
+	if _, ok := attrs[TruncatedFieldsKey]; ok {
+		t.Fatalf("expected no truncated fields attribute")
+	}
+}
+
+// TestSizeLimitsCapEntryKeepsStructuralKeys verifies structural attributes
+// survive the cap and payloads are replaced before larger other attributes.
+func TestSizeLimitsCapEntryKeepsStructuralKeys(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)),
+		WithSizeLimits(SizeLimits{MaxEntryBytes: 320}),
+		WithLabelFields("tenant"),
+		WithInsertIDs(),
+	)
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "msg",
+		"tenant", "acme",
+		"grpc.response.content", strings.Repeat("x", 100),
+		"note", strings.Repeat("y", 120),
+	)
+
+	r := rec.records[0]
+	if labels := valueToAny(attrValue(t, r, slogcp.LabelsGroup)); !reflect.DeepEqual(labels, map[string]any{"tenant": "acme"}) {
+		t.Fatalf("expected labels kept, got %v", labels)
+	}
+	attrs := collectAttrs(r)
+	if id, _ := attrs[InsertIDKey].(string); id == "" || strings.HasPrefix(id, "…truncated(") {
+		t.Fatalf("expected insert ID kept, got %q", id)
+	}
+	if content, _ := attrs["grpc.response.content"].(string); !strings.HasPrefix(content, "…truncated(") {
+		t.Fatalf("expected the payload replaced first, got %v", attrs["grpc.response.content"])
+	}
+	if attrs["note"] != strings.Repeat("y", 120) {
+		t.Fatalf("expected the larger non-payload attribute kept, got %v", attrs["note"])
+	}
+}
+
+// TestSizeLimitsTruncateListsAndMaps verifies the strings inside repeated
+// fields and maps are shortened and their length capped.
+func TestSizeLimitsTruncateListsAndMaps(t *testing.T) {
+	customer := newTestMessage(t, "Customer")
+	tags := customer.Mutable(customer.Descriptor().Fields().ByName("tags")).List()
+	for _, tag := range []string{"vip", "contact bob@example.com", "loyal"} {
+		tags.Append(protoreflect.ValueOfString(tag))
+	}
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithSizeLimits(SizeLimits{MaxStringBytes: 8, MaxGroupAttrs: 2}))
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "request received",
+		"grpc.request.content", customer,
+		"regions", map[string]string{"a": "eu", "b": "us-central1", "c": "asia"},
+	)
+
+	r := rec.records[0]
+	content, _ := valueToAny(attrValue(t, r, "grpc.request.content")).(map[string]any)
+	wantTags := []any{"vip", "contact …truncated(15 bytes)", "…truncated(9 bytes)"}
+	if !reflect.DeepEqual(content["tags"], wantTags) {
+		t.Fatalf("expected capped tags %v, got %v", wantTags, content["tags"])
+	}
+	wantRegions := map[string]any{"a": "eu", "b": "us-centr…truncated(3 bytes)", truncatedGroupKey: "…truncated(12 bytes)"}
+	if got := valueToAny(attrValue(t, r, "regions")); !reflect.DeepEqual(got, wantRegions) {
+		t.Fatalf("expected capped regions %v, got %v", wantRegions, got)
+	}
+	wantPaths := []string{"grpc.request.content.tags.1", "grpc.request.content.tags", "regions.b", "regions"}
+	if got := collectAttrs(r)[TruncatedFieldsKey]; !reflect.DeepEqual(got, wantPaths) {
+		t.Fatalf("expected truncated fields %v, got %v", wantPaths, got)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// SourceMode selects the source location recorded for adapter entries, which
+// slogcp reports as logging.googleapis.com/sourceLocation when configured to
+// add sources.
+type SourceMode int
+
+const (
+	// SourceNone records no source location.
+	SourceNone SourceMode = iota
+	// SourceHandler points server entries at the service method that handles
+	// the call, found on the registered service implementation. Entries
+	// without a handler, such as client calls, fall back to [SourceCaller].
+	SourceHandler
+	// SourceCaller points entries at the first stack frame outside gRPC,
+	// go-grpc-middleware and the adapter, such as the code that started a
+	// client call. Entries with no such frame record no source location.
+	SourceCaller
+)
+
+// adapterPkg prefixes the function names of this package's frames.
+var adapterPkg = reflect.TypeOf((*Logger)(nil)).Elem().PkgPath() + "."
+
+// plumbingPrefixes prefix the function names of frames SourceCaller skips.
+var plumbingPrefixes = []string{
+	"google.golang.org/grpc",
+	"github.com/grpc-ecosystem/go-grpc-middleware/",
+	"runtime.",
+}
+
+// WithSourceLocation makes the Logger record source locations for its entries
+// according to mode. The default is [SourceNone]: the function that wrote an
+// adapter entry is always adapter or middleware code and says nothing useful.
+// [SourceCaller] walks the stack on every entry, so it costs more than the
+// other modes.
+func WithSourceLocation(mode SourceMode) LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.sourceMode = mode
+	}
+}
+
+// sourcePC returns the program counter to record for an entry of call, which
+// may be nil, or 0 for none.
+func (l *Logger) sourcePC(call *callState) uintptr {
+	switch l.sourceMode {
+	case SourceHandler:
+		if call != nil {
+			if pc := call.handlerPC.Load(); pc != 0 {
+				return pc
+			}
+		}
+		return callerPC()
+	case SourceCaller:
+		return callerPC()
+	default:
+		return 0
+	}
+}
+
+// setHandler records the service method of srv that handles call when
+// entries should point at it.
+func (l *Logger) setHandler(call *callState, srv any) {
+	if l.sourceMode != SourceHandler || srv == nil {
+		return
+	}
+	_, method := splitFullMethod(call.method)
+	key := handlerKey{typ: reflect.TypeOf(srv), method: method}
+	if pc, ok := l.handlerPCs.Load(key); ok {
+		call.handlerPC.Store(pc.(uintptr))
+		return
+	}
+	pc := methodPC(key.typ, method)
+	l.handlerPCs.Store(key, pc)
+	call.handlerPC.Store(pc)
+}
+
+// handlerKey identifies a service method of an implementation type.
+type handlerKey struct {
+	typ    reflect.Type
+	method string
+}
+
+// methodPC returns a program counter inside the named method of typ, or 0.
+// Value receiver methods are looked up first so that pointers to service
+// structs resolve to the method itself rather than a generated wrapper.
+func methodPC(typ reflect.Type, method string) uintptr {
+	if typ.Kind() == reflect.Pointer {
+		if m, ok := typ.Elem().MethodByName(method); ok {
+			return m.Func.Pointer() + 1
+		}
+	}
+	if m, ok := typ.MethodByName(method); ok {
+		return m.Func.Pointer() + 1
+	}
+	return 0
+}
+
+// callerPC returns the program counter of the first frame outside gRPC,
+// go-grpc-middleware and the adapter, or 0.
+func callerPC() uintptr {
+	var pcs [64]uintptr
+	n := runtime.Callers(2, pcs[:])
+	for _, pc := range pcs[:n] {
+		frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		if !isPlumbing(frame) {
+			return pc
+		}
+	}
+	return 0
+}
+
+// isPlumbing reports whether frame belongs to gRPC, the middleware, the
+// runtime or the adapter itself.
+func isPlumbing(frame runtime.Frame) bool {
+	if strings.HasPrefix(frame.Function, adapterPkg) {
+		return true
+	}
+	for _, p := range plumbingPrefixes {
+		if strings.HasPrefix(frame.Function, p) {
+			return true
+		}
+	}
+	return false
+}
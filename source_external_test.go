@@ -0,0 +1,54 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+
+	slogcpadapter "github.com/pjscruggs/slogcp-grpc-adapter"
+)
+
+// TestSourceCallerSkipsPlumbing verifies entries point at the first frame
+// outside gRPC, the middleware and the adapter. It lives outside the package
+// so that its own frames are not adapter frames.
+func TestSourceCallerSkipsPlumbing(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: true, Level: slog.LevelDebug}))
+	logger := slogcpadapter.NewLogger(nil, slogcpadapter.WithLogger(base), slogcpadapter.WithSourceLocation(slogcpadapter.SourceHandler))
+	interceptor := logger.UnaryClientInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.FinishCall))
+
+	invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error { return nil }
+	_ = interceptor(context.Background(), "/pkg.Greeter/Get", nil, nil, nil, invoker)
+
+	var line struct {
+		Source struct {
+			Function string `json:"function"`
+		} `json:"source"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("decoding entry: %v", err)
+	}
+	if fn := line.Source.Function; !strings.HasSuffix(fn, "_test.TestSourceCallerSkipsPlumbing") {
+		t.Fatalf("expected client entries to point at the caller, got %q", fn)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"runtime"
+	"strings"
+	"testing"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// testGreeter stands in for a registered service implementation.
+type testGreeter struct{}
+
+// Get is the unary method of testGreeter.
+func (*testGreeter) Get(context.Context, any) (any, error) { return nil, nil }
+
+// Watch is the streaming method of testGreeter.
+func (testGreeter) Watch(any, grpc.ServerStream) error { return nil }
+
+// sourceFunction returns the function name recorded as r's source, or "".
+func sourceFunction(r slog.Record) string {
+	if r.PC == 0 {
+		return ""
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+	return frame.Function
+}
+
+// TestSourceHandlerPointsAtServiceMethod verifies server entries point at the
+// implementation's method for unary and stream calls.
+func TestSourceHandlerPointsAtServiceMethod(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithSourceLocation(SourceHandler))
+	opts := grpc_logging.WithLogOnEvents(grpc_logging.StartCall, grpc_logging.FinishCall)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{}})
+	srv := &testGreeter{}
+
+	unary := logger.UnaryServerInterceptor(opts)
+	for range 2 {
+		_, _ = unary(ctx, nil, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/pkg.Greeter/Get"}, srv.Get)
+	}
+	stream := logger.StreamServerInterceptor(opts)
+	_ = stream(srv, &fakeServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/pkg.Greeter/Watch"}, srv.Watch)
+
+	if len(rec.records) < 5 {
+		t.Fatalf("expected unary and stream records, got %d", len(rec.records))
+	}
+	for i, r := range rec.records {
+		want := "(*testGreeter).Get"
+		if i >= 4 {
+			want = "testGreeter.Watch"
+		}
+		if fn := sourceFunction(r); !strings.HasSuffix(fn, want) {
+			t.Fatalf("record %d: expected source in %s, got %q", i, want, fn)
+		}
+	}
+}
+
+// TestSourceNoneByDefault verifies no source location is recorded by default.
+func TestSourceNoneByDefault(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)))
+	runWithPeer(t, logger, rec, &peer.Peer{Addr: &net.TCPAddr{}})
+	if rec.records[0].PC != 0 {
+		t.Fatalf("expected no source, got %q", sourceFunction(rec.records[0]))
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"log/slog"
+	"sync"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+)
+
+// PendingSummaryKey marks a started call entry that [Logger.FlushCallSummaries]
+// wrote because its call had not finished.
+const PendingSummaryKey = "summary_pending"
+
+// WithCallSummaries makes the Logger's interceptors log one entry per call
+// instead of a started call and finished call pair. The started call entry is
+// held in the call's state, and its fields that the finish entry lacks are
+// added to the finish entry. Log both events, for example with
+// grpc_logging.WithLogOnEvents(grpc_logging.StartCall, grpc_logging.FinishCall);
+// with only FinishCall there is nothing to hold.
+//
+// Held entries of server calls that are still open at shutdown are written by
+// [Logger.FlushCallSummaries], which [Logger.Stop] calls. WithCallSummaries
+// implies [WithInFlightRegistry].
+func WithCallSummaries() LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.summaries = true
+		cfg.inFlightRegistry = true
+	}
+}
+
+// callSummary holds a call's started call entry until its finish entry.
+type callSummary struct {
+	mu      sync.Mutex
+	level   slog.Level
+	start   []slog.Attr
+	pending bool
+	// started is set once a finish entry has taken in the held start entry.
+	started bool
+	// flushed is set once FlushCallSummaries has seen the call; later
+	// entries are logged as they come.
+	flushed bool
+}
+
+// summarize holds the started call entry of call and merges it into the
+// finish entry. It reports false when the entry is held and must not be
+// written yet.
+func (l *Logger) summarize(call *callState, msg string, level slog.Level, attrs []slog.Attr) ([]slog.Attr, bool) {
+	if call == nil || call.summary == nil {
+		return attrs, true
+	}
+	event, known := classifyEvent(msg)
+	if !known {
+		return attrs, true
+	}
+	s := call.summary
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case event == grpc_logging.StartCall && !s.flushed:
+		s.level, s.start, s.pending = level, attrs, true
+		return nil, false
+	case event == grpc_logging.FinishCall:
+		start := s.start
+		s.started = s.pending
+		s.start, s.pending = nil, false
+		return mergeStartAttrs(attrs, start), true
+	default:
+		return attrs, true
+	}
+}
+
+// merged reports whether the call's finish entry took in its start entry,
+// making it the call's only entry for the operation. s may be nil.
+func (s *callSummary) merged() bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.started
+}
+
+// mergeStartAttrs appends the attributes of start whose keys attrs lacks.
+func mergeStartAttrs(attrs, start []slog.Attr) []slog.Attr {
+	if len(start) == 0 {
+		return attrs
+	}
+	seen := make(map[string]bool, len(attrs))
+	for _, a := range attrs {
+		seen[a.Key] = true
+	}
+	for _, a := range start {
+		if !seen[a.Key] {
+			attrs = append(attrs, a)
+		}
+	}
+	return attrs
+}
+
+// FlushCallSummaries writes the held started call entry of every open server
+// call, marked with [PendingSummaryKey], and returns how many were written.
+// Those calls' finish entries are then logged without the start fields.
+// Call it when a process is about to exit with calls still running, such as
+// from a signal handler; [Logger.Stop] calls it after stopping the server.
+// It returns 0 when the Logger was created without [WithCallSummaries].
+//
+// Example:
+//
+//	adapter := slogcpadapter.NewLogger(handler, slogcpadapter.WithCallSummaries())
+//	defer adapter.FlushCallSummaries()
+func (l *Logger) FlushCallSummaries() int {
+	if l == nil || l.registry == nil || l.log == nil {
+		return 0
+	}
+	n := 0
+	for _, call := range l.registry.snapshot() {
+		s := call.summary
+		if s == nil {
+			continue
+		}
+		s.mu.Lock()
+		level, attrs, pending := s.level, s.start, s.pending
+		s.start, s.pending, s.flushed = nil, false, true
+		s.mu.Unlock()
+		if !pending {
+			continue
+		}
+		l.emit(call.ctx, call, level, "started call", append(attrs, slog.Bool(PendingSummaryKey, true)))
+		n++
+	}
+	return n
+}
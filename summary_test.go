@@ -0,0 +1,133 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"testing"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// TestCallSummariesLogOneEntryPerCall verifies the interceptors write a single
+// finish entry carrying the start fields.
+func TestCallSummariesLogOneEntryPerCall(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithCallSummaries())
+	interceptor := logger.UnaryServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.StartCall, grpc_logging.FinishCall))
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 80}})
+
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Get"}, func(context.Context, any) (any, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(rec.records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(rec.records))
+	}
+	r := rec.records[0]
+	attrs := collectAttrs(r)
+	if r.Message != "finished call" || attrs["grpc.code"] != "OK" || attrs["peer.address"] != "10.0.0.1:80" {
+		t.Fatalf("expected a finish entry with request fields, got %q %v", r.Message, attrs)
+	}
+}
+
+// TestCallSummariesMergeStartOnlyFields verifies start fields missing from the
+// finish entry are added to it and shared keys keep the finish value.
+func TestCallSummariesMergeStartOnlyFields(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithCallSummaries())
+	ctx, call := logger.beginCall(context.Background(), "/pkg.Svc/Get", false)
+	defer logger.endCall(call)
+
+	logger.Log(ctx, grpc_logging.LevelInfo, "started call", "tenant", "acme", "grpc.time_ms", "1")
+	logger.Log(ctx, grpc_logging.LevelInfo, "finished call", "grpc.code", "OK", "grpc.time_ms", "12")
+
+	if len(rec.records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(rec.records))
+	}
+	attrs := collectAttrs(rec.records[0])
+	if attrs["tenant"] != "acme" || attrs["grpc.time_ms"] != 12.0 {
+		t.Fatalf("expected merged start fields, got %v", attrs)
+	}
+}
+
+// TestFlushCallSummariesWritesPendingStarts verifies open calls' held entries
+// are written once and their finish entries are then logged alone.
+func TestFlushCallSummariesWritesPendingStarts(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithCallSummaries())
+	ctx, call := logger.beginCall(context.Background(), "/pkg.Svc/Get", false)
+	_, idle := logger.beginCall(context.Background(), "/pkg.Svc/Watch", false)
+	defer logger.endCall(idle)
+
+	logger.Log(ctx, grpc_logging.LevelInfo, "started call", "tenant", "acme")
+	if n := logger.FlushCallSummaries(); n != 1 {
+		t.Fatalf("expected 1 flushed call, got %d", n)
+	}
+	if n := logger.FlushCallSummaries(); n != 0 {
+		t.Fatalf("expected nothing left to flush, got %d", n)
+	}
+	logger.Log(ctx, grpc_logging.LevelInfo, "finished call", "grpc.code", "Canceled")
+	logger.endCall(call)
+
+	if len(rec.records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(rec.records))
+	}
+	start, finish := rec.records[0], rec.records[1]
+	if got := collectAttrs(start); start.Message != "started call" || got[PendingSummaryKey] != true || got["tenant"] != "acme" {
+		t.Fatalf("expected a pending start entry, got %q %v", start.Message, got)
+	}
+	if !start.Time.Equal(call.start) {
+		t.Errorf("expected the start entry at the call start %v, got %v", call.start, start.Time)
+	}
+	if got := collectAttrs(finish); finish.Message != "finished call" || got["tenant"] != nil {
+		t.Fatalf("expected a plain finish entry, got %q %v", finish.Message, got)
+	}
+}
+
+// TestFlushCallSummariesWithoutOption verifies the flush is a no-op by default.
+func TestFlushCallSummariesWithoutOption(t *testing.T) {
+	logger := NewLogger(nil, WithLogger(slog.New(&recordingHandler{})), WithInFlightRegistry())
+	ctx, call := logger.beginCall(context.Background(), "/pkg.Svc/Get", false)
+	defer logger.endCall(call)
+	logger.Log(ctx, grpc_logging.LevelInfo, "started call")
+	if n := logger.FlushCallSummaries(); n != 0 {
+		t.Fatalf("expected 0 flushed calls, got %d", n)
+	}
+}
+
+// TestCallSummariesMarkFirstAndLast verifies a summary entry is both the first
+// and the last entry of its operation.
+func TestCallSummariesMarkFirstAndLast(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithCallSummaries(), WithOperations())
+	ctx, call := logger.beginCall(context.Background(), "/pkg.Svc/Get", false)
+	defer logger.endCall(call)
+
+	logger.Log(ctx, grpc_logging.LevelInfo, "started call")
+	logger.Log(ctx, grpc_logging.LevelInfo, "finished call", "grpc.code", "OK")
+
+	op, _ := valueToAny(attrValue(t, rec.records[0], OperationKey)).(map[string]any)
+	if op["first"] != true || op["last"] != true {
+		t.Fatalf("expected first and last on the summary entry, got %v", op)
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+)
+
+// TerminationCause explains who ended a canceled or deadline-exceeded call.
+type TerminationCause string
+
+const (
+	// TerminationClientCancel means the caller canceled the call.
+	TerminationClientCancel TerminationCause = "client_cancel"
+	// TerminationClientDeadline means the caller's deadline expired.
+	TerminationClientDeadline TerminationCause = "client_deadline"
+	// TerminationServerDeadline means the server reported DeadlineExceeded while
+	// the caller's own deadline had not expired, typically from a downstream timeout.
+	TerminationServerDeadline TerminationCause = "server_deadline"
+	// TerminationServerShutdown means the call was cut short by server shutdown.
+	TerminationServerShutdown TerminationCause = "server_shutdown"
+	// TerminationTransportClosed means the underlying connection closed under the call.
+	TerminationTransportClosed TerminationCause = "transport_closed"
+)
+
+// WithTerminationLevelMapper makes [NewLogger] use mapper to choose the level of
+// finish entries that carry a termination_cause. mapper receives the level chosen
+// by go-grpc-middleware and returns the level to log at. Escalation by
+// [WithDeadlineBudget] is applied afterwards, so mapper cannot lower it. A nil
+// mapper is ignored.
+//
+// To keep go-grpc-middleware's levels and only add the attribute:
+//
+//	slogcpadapter.WithTerminationLevelMapper(func(_ slogcpadapter.TerminationCause, level slog.Level) slog.Level {
+//		return level
+//	})
+func WithTerminationLevelMapper(mapper func(TerminationCause, slog.Level) slog.Level) LoggerOption {
+	return func(cfg *loggerConfig) {
+		if mapper != nil {
+			cfg.terminationLevelMapper = mapper
+		}
+	}
+}
+
+// defaultTerminationLevel keeps caller-driven and shutdown terminations out of
+// error alerting while leaving server-side deadline failures at WARN or above.
+func defaultTerminationLevel(cause TerminationCause, level slog.Level) slog.Level {
+	switch cause {
+	case TerminationClientCancel, TerminationClientDeadline, TerminationTransportClosed, TerminationServerShutdown:
+		return slog.LevelInfo
+	case TerminationServerDeadline:
+		return max(level, slog.LevelWarn)
+	default:
+		return level
+	}
+}
+
+// Stop stops server immediately. The calls it cancels are attributed to
+// server_shutdown; calls of other servers sharing the Logger, and calls that
+// start later, are not. The held entries of calls still open are written by
+// [Logger.FlushCallSummaries].
+//
+// The Logger cannot tell which server a call arrived on, so while server.Stop
+// runs every server call in progress is marked, and the marks of calls it left
+// running are cleared once it returns. A call of another server whose client
+// cancels it during that window is therefore also attributed to
+// server_shutdown.
+//
+// grpc.Server.GracefulStop lets in-flight calls finish instead of canceling
+// them, so it needs no wrapper: a cancellation seen while a server drains
+// comes from the client or the transport.
+func (l *Logger) Stop(server *grpc.Server) {
+	var marked []*callState
+	if l.calls != nil {
+		for _, call := range l.calls.snapshot() {
+			if call.ctx.Err() == nil {
+				call.stopped.Store(true)
+				marked = append(marked, call)
+			}
+		}
+	}
+	server.Stop()
+	for _, call := range marked {
+		if call.ctx.Err() == nil {
+			call.stopped.Store(false)
+		}
+	}
+	l.FlushCallSummaries()
+}
+
+// StatsHandler returns a [stats.Handler] that lets the Logger tell transport
+// closures apart from client cancellations. Install it on the server with
+// grpc.StatsHandler alongside the Logger's interceptors. Detection is best
+// effort: a call that logs its finish entry before gRPC reports the connection
+// end is still attributed to client_cancel.
+func (l *Logger) StatsHandler() stats.Handler {
+	return connStatsHandler{}
+}
+
+// connState records whether a server connection has ended.
+type connState struct {
+	closed atomic.Bool
+}
+
+type connStateKey struct{}
+
+// connStatsHandler tracks connection lifetimes for termination classification.
+type connStatsHandler struct{}
+
+// TagConn attaches a connState to the connection context.
+func (connStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return context.WithValue(ctx, connStateKey{}, &connState{})
+}
+
+// HandleConn marks the connection closed when it ends.
+func (connStatsHandler) HandleConn(ctx context.Context, s stats.ConnStats) {
+	if _, ok := s.(*stats.ConnEnd); !ok {
+		return
+	}
+	if state, ok := ctx.Value(connStateKey{}).(*connState); ok {
+		state.closed.Store(true)
+	}
+}
+
+// TagRPC returns ctx unchanged.
+func (connStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+// HandleRPC ignores per-RPC stats.
+func (connStatsHandler) HandleRPC(context.Context, stats.RPCStats) {}
+
+// connClosed reports whether the connection carrying ctx has ended.
+func connClosed(ctx context.Context) bool {
+	state, ok := ctx.Value(connStateKey{}).(*connState)
+	return ok && state.closed.Load()
+}
+
+// classifyTermination determines why call ended with code.
+// It returns "" for codes other than Canceled and DeadlineExceeded or when the
+// cause cannot be determined.
+func (l *Logger) classifyTermination(call *callState, code codes.Code) TerminationCause {
+	if code != codes.Canceled && code != codes.DeadlineExceeded {
+		return ""
+	}
+	ctxErr := call.ctx.Err()
+	switch {
+	case errors.Is(ctxErr, context.DeadlineExceeded):
+		return TerminationClientDeadline
+	case errors.Is(ctxErr, context.Canceled):
+		return l.classifyCancel(call)
+	case code == codes.DeadlineExceeded:
+		return TerminationServerDeadline
+	default:
+		return ""
+	}
+}
+
+// classifyCancel attributes a canceled call context.
+func (l *Logger) classifyCancel(call *callState) TerminationCause {
+	switch {
+	case call.isClient:
+		return TerminationClientCancel
+	case call.stopped.Load():
+		return TerminationServerShutdown
+	case connClosed(call.ctx):
+		return TerminationTransportClosed
+	default:
+		return TerminationClientCancel
+	}
+}
+
+// annotateTermination adds termination_cause to finish entries of canceled or
+// deadline-exceeded calls and picks their level.
+func (l *Logger) annotateTermination(call *callState, level slog.Level, attrs []slog.Attr) (slog.Level, []slog.Attr) {
+	code, ok := codeFromAttrs(attrs)
+	if !ok {
+		return level, attrs
+	}
+	cause := l.classifyTermination(call, code)
+	if cause == "" {
+		return level, attrs
+	}
+	attrs = append(attrs, slog.String("termination_cause", string(cause)))
+	if detail := context.Cause(call.ctx); detail != nil && !errors.Is(detail, call.ctx.Err()) {
+		attrs = append(attrs, slog.String("termination_detail", detail.Error()))
+	}
+	if l.terminationLevel != nil {
+		level = l.terminationLevel(cause, level)
+	}
+	return level, attrs
+}
+
+// codeFromAttrs finds the grpc.code attribute written by go-grpc-middleware.
+func codeFromAttrs(attrs []slog.Attr) (codes.Code, bool) {
+	for _, a := range attrs {
+		if a.Key == "grpc.code" && a.Value.Kind() == slog.KindString {
+			code, ok := codeByName[a.Value.String()]
+			return code, ok
+		}
+	}
+	return 0, false
+}
+
+// codeByName maps codes.Code String() values back to their codes.
+var codeByName = func() map[string]codes.Code {
+	m := make(map[string]codes.Code, int(codes.Unauthenticated)+1)
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		m[c.String()] = c
+	}
+	return m
+}()
@@ -0,0 +1,253 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// runUnaryFinish runs one unary server call through logger and returns its finish record.
+func runUnaryFinish(t *testing.T, logger *Logger, rec *recordingHandler, ctx context.Context, handlerErr error) slog.Record {
+	t.Helper()
+	interceptor := logger.UnaryServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.FinishCall))
+	info := &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Get"}
+	_, _ = interceptor(ctx, nil, info, func(context.Context, any) (any, error) { return nil, handlerErr })
+	if len(rec.records) == 0 {
+		t.Fatalf("expected a finish record")
+	}
+	return rec.records[len(rec.records)-1]
+}
+
+// TestTerminationCauseClassification verifies each cause the adapter can infer for server calls.
+func TestTerminationCauseClassification(t *testing.T) {
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	handler := connStatsHandler{}
+	connCtx := handler.TagConn(context.Background(), &stats.ConnTagInfo{})
+	closedCtx, cancelClosed := context.WithCancel(connCtx)
+	handler.HandleConn(connCtx, &stats.ConnEnd{})
+	cancelClosed()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		err       error
+		want      TerminationCause
+		wantLevel slog.Level
+	}{
+		{"client-deadline", expired, status.Error(codes.DeadlineExceeded, "slow"), TerminationClientDeadline, slog.LevelInfo},
+		{"client-cancel", canceled, status.Error(codes.Canceled, "gone"), TerminationClientCancel, slog.LevelInfo},
+		{"server-deadline", context.Background(), status.Error(codes.DeadlineExceeded, "downstream"), TerminationServerDeadline, slog.LevelWarn},
+		{"transport-closed", closedCtx, status.Error(codes.Canceled, "closed"), TerminationTransportClosed, slog.LevelInfo},
+	}
+	for _, tt := range tests {
+		rec := &recordingHandler{}
+		logger := NewLogger(nil, WithLogger(slog.New(rec)))
+		r := runUnaryFinish(t, logger, rec, tt.ctx, tt.err)
+		if got := collectAttrs(r)["termination_cause"]; got != string(tt.want) {
+			t.Fatalf("%s: expected cause %q, got %v", tt.name, tt.want, got)
+		}
+		if r.Level != tt.wantLevel {
+			t.Fatalf("%s: expected level %v, got %v", tt.name, tt.wantLevel, r.Level)
+		}
+	}
+}
+
+// blockingServer serves one unary method, /<service>/Wait, that blocks until
+// its call is canceled. Each finished call's record is sent on done once the
+// Logger has written it.
+type blockingServer struct {
+	server  *grpc.Server
+	conn    *grpc.ClientConn
+	started chan struct{}
+	done    chan slog.Record
+}
+
+// newBlockingServer starts a blockingServer for service logging through logger.
+func newBlockingServer(t *testing.T, logger *Logger, rec *recordingHandler, service string) *blockingServer {
+	t.Helper()
+	b := &blockingServer{started: make(chan struct{}, 1), done: make(chan slog.Record, 1)}
+	signal := func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		b.done <- rec.records[len(rec.records)-1]
+		return resp, err
+	}
+	b.server = grpc.NewServer(grpc.ChainUnaryInterceptor(signal, logger.UnaryServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.FinishCall))))
+	b.server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: service,
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Wait",
+			Handler: func(_ any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				if err := dec(&emptypb.Empty{}); err != nil {
+					return nil, err
+				}
+				info := &grpc.UnaryServerInfo{FullMethod: "/" + service + "/Wait"}
+				return interceptor(ctx, nil, info, func(ctx context.Context, _ any) (any, error) {
+					b.started <- struct{}{}
+					<-ctx.Done()
+					return nil, status.FromContextError(ctx.Err()).Err()
+				})
+			},
+		}},
+	}, struct{}{})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	go func() { _ = b.server.Serve(lis) }()
+	b.conn, err = grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = b.conn.Close()
+		b.server.Stop()
+	})
+	return b
+}
+
+// call starts a call to the server's Wait method and waits until its handler runs.
+func (b *blockingServer) call(t *testing.T, ctx context.Context, service string) {
+	t.Helper()
+	go func() { _ = b.conn.Invoke(ctx, "/"+service+"/Wait", &emptypb.Empty{}, &emptypb.Empty{}) }()
+	select {
+	case <-b.started:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("%s: call did not start", service)
+	}
+}
+
+// finished waits for the record of the server's finished call.
+func (b *blockingServer) finished(t *testing.T) slog.Record {
+	t.Helper()
+	select {
+	case r := <-b.done:
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatalf("call did not finish")
+		return slog.Record{}
+	}
+}
+
+// TestTerminationCauseServerShutdown verifies only the calls of the server
+// being stopped are attributed to server_shutdown when another server shares
+// the Logger, and that calls started after Stop are not.
+func TestTerminationCauseServerShutdown(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)))
+	stopped := newBlockingServer(t, logger, rec, "test.Stopped")
+	running := newBlockingServer(t, logger, rec, "test.Running")
+
+	stopped.call(t, context.Background(), "test.Stopped")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	running.call(t, ctx, "test.Running")
+
+	logger.Stop(stopped.server)
+	if got := collectAttrs(stopped.finished(t))["termination_cause"]; got != string(TerminationServerShutdown) {
+		t.Fatalf("expected the stopped server's call to be server_shutdown, got %v", got)
+	}
+	cancel()
+	if got := collectAttrs(running.finished(t))["termination_cause"]; got != string(TerminationClientCancel) {
+		t.Fatalf("expected the other server's call to be client_cancel, got %v", got)
+	}
+
+	canceled, cancelLater := context.WithCancel(context.Background())
+	cancelLater()
+	r := runUnaryFinish(t, logger, rec, canceled, status.Error(codes.Canceled, "gone"))
+	if got := collectAttrs(r)["termination_cause"]; got != string(TerminationClientCancel) {
+		t.Fatalf("expected a call started after Stop to be client_cancel, got %v", got)
+	}
+}
+
+// TestTerminationDetailFromCause verifies custom cancellation causes are logged.
+func TestTerminationDetailFromCause(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)))
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errors.New("load shed"))
+	r := runUnaryFinish(t, logger, rec, ctx, status.Error(codes.Canceled, "canceled"))
+	if got := collectAttrs(r)["termination_detail"]; got != "load shed" {
+		t.Fatalf("expected termination detail, got %v", got)
+	}
+}
+
+// TestTerminationIgnoresOtherCodes verifies unrelated outcomes carry no cause.
+func TestTerminationIgnoresOtherCodes(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)))
+	r := runUnaryFinish(t, logger, rec, context.Background(), status.Error(codes.Internal, "boom"))
+	if _, ok := collectAttrs(r)["termination_cause"]; ok {
+		t.Fatalf("expected no termination cause for Internal")
+	}
+	if r.Level != slog.LevelError {
+		t.Fatalf("expected middleware level to be kept, got %v", r.Level)
+	}
+}
+
+// TestTerminationLevelMapperOverride verifies a custom mapper controls the level.
+func TestTerminationLevelMapperOverride(t *testing.T) {
+	rec := &recordingHandler{}
+	keep := func(_ TerminationCause, level slog.Level) slog.Level { return level }
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithTerminationLevelMapper(keep), WithTerminationLevelMapper(nil))
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	r := runUnaryFinish(t, logger, rec, ctx, status.Error(codes.DeadlineExceeded, "slow"))
+	if r.Level != slog.LevelWarn {
+		t.Fatalf("expected middleware WARN level to be kept, got %v", r.Level)
+	}
+}
+
+// TestTerminationKeepsDeadlineBudgetEscalation verifies the termination level
+// does not undo deadline budget escalation.
+func TestTerminationKeepsDeadlineBudgetEscalation(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithDeadlineBudget(DeadlineBudget{ErrorRatio: 0.9}))
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(20*time.Millisecond))
+	defer cancel()
+	interceptor := logger.UnaryServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.FinishCall))
+	_, _ = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Get"}, func(ctx context.Context, _ any) (any, error) {
+		<-ctx.Done()
+		return nil, status.Error(codes.DeadlineExceeded, "slow")
+	})
+
+	r := rec.records[len(rec.records)-1]
+	if got := collectAttrs(r)["termination_cause"]; got != string(TerminationClientDeadline) {
+		t.Fatalf("expected client_deadline, got %v", got)
+	}
+	if r.Level != slog.LevelError {
+		t.Fatalf("expected the budget escalation to ERROR to be kept, got %v", r.Level)
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// testFileDescriptor returns the descriptor for the test-only schema:
+//
+//	syntax = "proto3";
+//	package slogcpadapter.test;
+//
+//	message Card { string number = 1; }
+//	message Customer {
+//	  string email = 1;
+//	  string name = 2;
+//	  Card card = 3;
+//	  repeated string tags = 4;
+//	  map<string, string> attrs = 5;
+//	  int64 id = 6;
+//	  string display_name = 7;
+//	}
+var testFileDescriptor = sync.OnceValue(func() protoreflect.FileDescriptor {
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	msg := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("slogcpadapter/test.proto"),
+		Package: proto.String("slogcpadapter.test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Card"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("number"), Number: proto.Int32(1), Label: optional, Type: str, JsonName: proto.String("number")},
+				},
+			},
+			{
+				Name: proto.String("Customer"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("email"), Number: proto.Int32(1), Label: optional, Type: str, JsonName: proto.String("email")},
+					{Name: proto.String("name"), Number: proto.Int32(2), Label: optional, Type: str, JsonName: proto.String("name")},
+					{Name: proto.String("card"), Number: proto.Int32(3), Label: optional, Type: msg, TypeName: proto.String(".slogcpadapter.test.Card"), JsonName: proto.String("card")},
+					{Name: proto.String("tags"), Number: proto.Int32(4), Label: repeated, Type: str, JsonName: proto.String("tags")},
+					{Name: proto.String("attrs"), Number: proto.Int32(5), Label: repeated, Type: msg, TypeName: proto.String(".slogcpadapter.test.Customer.AttrsEntry"), JsonName: proto.String("attrs")},
+					{Name: proto.String("id"), Number: proto.Int32(6), Label: optional, Type: descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum(), JsonName: proto.String("id")},
+					{Name: proto.String("display_name"), Number: proto.Int32(7), Label: optional, Type: str, JsonName: proto.String("displayName")},
+				},
+				NestedType: []*descriptorpb.DescriptorProto{
+					{
+						Name: proto.String("AttrsEntry"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{Name: proto.String("key"), Number: proto.Int32(1), Label: optional, Type: str, JsonName: proto.String("key")},
+							{Name: proto.String("value"), Number: proto.Int32(2), Label: optional, Type: str, JsonName: proto.String("value")},
+						},
+						Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+					},
+				},
+			},
+		},
+	}
+	fd, err := protodesc.NewFile(file, nil)
+	if err != nil {
+		panic(err)
+	}
+	return fd
+})
+
+// newTestMessage returns an empty dynamic message of the named test type.
+func newTestMessage(t *testing.T, name protoreflect.Name) *dynamicpb.Message {
+	t.Helper()
+	md := testFileDescriptor().Messages().ByName(name)
+	if md == nil {
+		t.Fatalf("unknown test message %s", name)
+	}
+	return dynamicpb.NewMessage(md)
+}
+
+// newTestCustomer returns a populated Customer test message.
+func newTestCustomer(t *testing.T) *dynamicpb.Message {
+	t.Helper()
+	customer := newTestMessage(t, "Customer")
+	fields := customer.Descriptor().Fields()
+	customer.Set(fields.ByName("email"), protoreflect.ValueOfString("ada@example.com"))
+	customer.Set(fields.ByName("name"), protoreflect.ValueOfString("Ada"))
+	customer.Set(fields.ByName("id"), protoreflect.ValueOfInt64(42))
+
+	card := customer.Mutable(fields.ByName("card")).Message()
+	card.Set(card.Descriptor().Fields().ByName("number"), protoreflect.ValueOfString("4111 1111 1111 1111"))
+
+	tags := customer.Mutable(fields.ByName("tags")).List()
+	tags.Append(protoreflect.ValueOfString("vip"))
+	tags.Append(protoreflect.ValueOfString("contact bob@example.com"))
+
+	attrs := customer.Mutable(fields.ByName("attrs")).Map()
+	attrs.Set(protoreflect.ValueOfString("session_token").MapKey(), protoreflect.ValueOfString("s3cr3t"))
+	attrs.Set(protoreflect.ValueOfString("region").MapKey(), protoreflect.ValueOfString("eu"))
+	return customer
+}
+
+// getString returns the string value at the dotted field path in m.
+func getString(m protoreflect.Message, fieldPath ...protoreflect.Name) string {
+	for _, name := range fieldPath[:len(fieldPath)-1] {
+		m = m.Get(m.Descriptor().Fields().ByName(name)).Message()
+	}
+	return m.Get(m.Descriptor().Fields().ByName(fieldPath[len(fieldPath)-1])).String()
+}
+
+// sensitiveSchema holds the descriptors of the test-only sensitivity schema:
+//
+//	// sensitive.proto
+//	package slogcpadapter.test;
+//	extend google.protobuf.FieldOptions { bool sensitive = 50001; }
+//
+//	// account.proto
+//	message Account {
+//	  string user = 1;
+//	  string password = 2 [debug_redact = true];
+//	  string ssn = 3 [(slogcpadapter.test.sensitive) = true];
+//	  oneof contact {
+//	    string phone = 4;
+//	    Account referrer = 5;
+//	  }
+//	  map<string, Account> linked = 6;
+//	  google.protobuf.Any extra = 7;
+//	}
+type sensitiveSchema struct {
+	account   protoreflect.MessageDescriptor
+	sensitive protoreflect.ExtensionType
+}
+
+var testSensitiveSchema = sync.OnceValue(func() sensitiveSchema {
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	msg := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+
+	files := new(protoregistry.Files)
+	mustRegister := func(fd protoreflect.FileDescriptor) {
+		if err := files.RegisterFile(fd); err != nil {
+			panic(err)
+		}
+	}
+	mustRegister(descriptorpb.File_google_protobuf_descriptor_proto)
+	mustRegister(anypb.File_google_protobuf_any_proto)
+
+	extFile, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("slogcpadapter/sensitive.proto"),
+		Package:    proto.String("slogcpadapter.test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/descriptor.proto"},
+		Extension: []*descriptorpb.FieldDescriptorProto{{
+			Name:     proto.String("sensitive"),
+			Number:   proto.Int32(50001),
+			Label:    optional,
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum(),
+			Extendee: proto.String(".google.protobuf.FieldOptions"),
+			JsonName: proto.String("sensitive"),
+		}},
+	}, files)
+	if err != nil {
+		panic(err)
+	}
+	mustRegister(extFile)
+	sensitive := dynamicpb.NewExtensionType(extFile.Extensions().Get(0))
+
+	ssnOpts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(ssnOpts, sensitive, true)
+
+	accountFile, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("slogcpadapter/account.proto"),
+		Package:    proto.String("slogcpadapter.test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/any.proto", "slogcpadapter/sensitive.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Account"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("user"), Number: proto.Int32(1), Label: optional, Type: str, JsonName: proto.String("user")},
+				{Name: proto.String("password"), Number: proto.Int32(2), Label: optional, Type: str, JsonName: proto.String("password"),
+					Options: &descriptorpb.FieldOptions{DebugRedact: proto.Bool(true)}},
+				{Name: proto.String("ssn"), Number: proto.Int32(3), Label: optional, Type: str, JsonName: proto.String("ssn"), Options: ssnOpts},
+				{Name: proto.String("phone"), Number: proto.Int32(4), Label: optional, Type: str, JsonName: proto.String("phone"), OneofIndex: proto.Int32(0)},
+				{Name: proto.String("referrer"), Number: proto.Int32(5), Label: optional, Type: msg, TypeName: proto.String(".slogcpadapter.test.Account"),
+					JsonName: proto.String("referrer"), OneofIndex: proto.Int32(0)},
+				{Name: proto.String("linked"), Number: proto.Int32(6), Label: descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(), Type: msg,
+					TypeName: proto.String(".slogcpadapter.test.Account.LinkedEntry"), JsonName: proto.String("linked")},
+				{Name: proto.String("extra"), Number: proto.Int32(7), Label: optional, Type: msg, TypeName: proto.String(".google.protobuf.Any"), JsonName: proto.String("extra")},
+			},
+			OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("contact")}},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("LinkedEntry"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("key"), Number: proto.Int32(1), Label: optional, Type: str, JsonName: proto.String("key")},
+					{Name: proto.String("value"), Number: proto.Int32(2), Label: optional, Type: msg, TypeName: proto.String(".slogcpadapter.test.Account"), JsonName: proto.String("value")},
+				},
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			}},
+		}},
+	}, files)
+	if err != nil {
+		panic(err)
+	}
+	account := accountFile.Messages().ByName("Account")
+	// Register the type globally so Any payloads holding an Account can be unpacked.
+	if err := protoregistry.GlobalTypes.RegisterMessage(dynamicpb.NewMessageType(account)); err != nil {
+		panic(err)
+	}
+	return sensitiveSchema{account: account, sensitive: sensitive}
+})
+
+// newTestAccount returns an Account with every sensitive field populated.
+func newTestAccount(user string) *dynamicpb.Message {
+	account := dynamicpb.NewMessage(testSensitiveSchema().account)
+	fields := account.Descriptor().Fields()
+	account.Set(fields.ByName("user"), protoreflect.ValueOfString(user))
+	account.Set(fields.ByName("password"), protoreflect.ValueOfString("hunter2"))
+	account.Set(fields.ByName("ssn"), protoreflect.ValueOfString("078-05-1120"))
+	return account
+}
+
+// testOrdersService registers and returns the test-only service schema in
+// protoregistry.GlobalFiles, where NewPayloadFieldSelector looks methods up:
+//
+//	syntax = "proto3";
+//	package slogcpadapter.svc;
+//
+//	message Order { string id = 1; string status = 2; repeated string items = 3; }
+//	message CreateOrderRequest {
+//	  string customer_id = 1;
+//	  Order order = 2;
+//	  string secret = 3 [debug_redact = true];
+//	}
+//	message CreateOrderResponse { Order order = 1; }
+//	service Orders { rpc Create(CreateOrderRequest) returns (CreateOrderResponse); }
+var testOrdersService = sync.OnceValue(func() protoreflect.ServiceDescriptor {
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	msg := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("slogcpadapter/orders.proto"),
+		Package: proto.String("slogcpadapter.svc"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Order"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("id"), Number: proto.Int32(1), Label: optional, Type: str, JsonName: proto.String("id")},
+					{Name: proto.String("status"), Number: proto.Int32(2), Label: optional, Type: str, JsonName: proto.String("status")},
+					{Name: proto.String("items"), Number: proto.Int32(3), Label: descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(), Type: str, JsonName: proto.String("items")},
+				},
+			},
+			{
+				Name: proto.String("CreateOrderRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("customer_id"), Number: proto.Int32(1), Label: optional, Type: str, JsonName: proto.String("customerId")},
+					{Name: proto.String("order"), Number: proto.Int32(2), Label: optional, Type: msg, TypeName: proto.String(".slogcpadapter.svc.Order"), JsonName: proto.String("order")},
+					{Name: proto.String("secret"), Number: proto.Int32(3), Label: optional, Type: str, JsonName: proto.String("secret"),
+						Options: &descriptorpb.FieldOptions{DebugRedact: proto.Bool(true)}},
+				},
+			},
+			{
+				Name: proto.String("CreateOrderResponse"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("order"), Number: proto.Int32(1), Label: optional, Type: msg, TypeName: proto.String(".slogcpadapter.svc.Order"), JsonName: proto.String("order")},
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Orders"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Create"),
+				InputType:  proto.String(".slogcpadapter.svc.CreateOrderRequest"),
+				OutputType: proto.String(".slogcpadapter.svc.CreateOrderResponse"),
+			}},
+		}},
+	}, nil)
+	if err != nil {
+		panic(err)
+	}
+	if err := protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+		panic(err)
+	}
+	return fd.Services().Get(0)
+})
+
+// newTestOrderMessage returns an empty dynamic message of the named orders type.
+func newTestOrderMessage(name protoreflect.Name) *dynamicpb.Message {
+	return dynamicpb.NewMessage(testOrdersService().ParentFile().Messages().ByName(name))
+}
+
+// setFields sets string fields of m from alternating name/value pairs.
+func setFields(m protoreflect.Message, nameValues ...string) {
+	for i := 0; i+1 < len(nameValues); i += 2 {
+		m.Set(m.Descriptor().Fields().ByName(protoreflect.Name(nameValues[i])), protoreflect.ValueOfString(nameValues[i+1]))
+	}
+}
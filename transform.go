@@ -0,0 +1,140 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Event is one entry on its way through the transformer chain.
+type Event struct {
+	// Context is the context the entry is logged with.
+	Context context.Context
+	// Time is the entry's timestamp.
+	Time time.Time
+	// PC is the program counter of the entry's source location, or 0 for
+	// none; see [WithSourceLocation].
+	PC uintptr
+	// Level is the entry's level after level mapping.
+	Level slog.Level
+	// Message is the entry's message.
+	Message string
+	// Attrs holds the entry's top-level attributes. Transformers may modify
+	// it in place or replace it.
+	Attrs []slog.Attr
+}
+
+// Transformer rewrites e in place and reports whether the entry should still
+// be logged. Returning false drops the entry and skips later transformers.
+type Transformer func(e *Event) bool
+
+// WithTransformers makes [Logger.Log] pass every entry through transformers,
+// in order, before it is written. Transformers run after redaction, payload
+// rendering and call annotations, and before chunking and [SizeLimits]. The
+// option may be given several times; nil transformers are ignored.
+//
+// Example:
+//
+//	adapter := slogcpadapter.NewLogger(handler,
+//		slogcpadapter.WithTransformers(
+//			slogcpadapter.RenameKey("peer.address", "client"),
+//			slogcpadapter.DropKeys("grpc.start_time"),
+//			slogcpadapter.AddStatic(slog.String("team", "payments")),
+//		),
+//	)
+func WithTransformers(transformers ...Transformer) LoggerOption {
+	return func(cfg *loggerConfig) {
+		for _, t := range transformers {
+			if t != nil {
+				cfg.transformers = append(cfg.transformers, t)
+			}
+		}
+	}
+}
+
+// RenameKey returns a Transformer that renames the top-level attribute from to to.
+func RenameKey(from, to string) Transformer {
+	return func(e *Event) bool {
+		for i := range e.Attrs {
+			if e.Attrs[i].Key == from {
+				e.Attrs[i].Key = to
+			}
+		}
+		return true
+	}
+}
+
+// DropKeys returns a Transformer that removes the named top-level attributes.
+func DropKeys(keys ...string) Transformer {
+	drop := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		drop[k] = true
+	}
+	return func(e *Event) bool {
+		kept := e.Attrs[:0]
+		for _, a := range e.Attrs {
+			if !drop[a.Key] {
+				kept = append(kept, a)
+			}
+		}
+		e.Attrs = kept
+		return true
+	}
+}
+
+// AddStatic returns a Transformer that appends attrs to every entry.
+func AddStatic(attrs ...slog.Attr) Transformer {
+	static := append([]slog.Attr(nil), attrs...)
+	return func(e *Event) bool {
+		e.Attrs = append(e.Attrs, static...)
+		return true
+	}
+}
+
+// MapValue returns a Transformer that replaces the value of the top-level
+// attribute key with fn's result. A nil fn leaves values unchanged.
+//
+// Example:
+//
+//	slogcpadapter.MapValue("grpc.time_ms", func(v slog.Value) slog.Value {
+//		return slog.StringValue(fmt.Sprintf("%.1fms", v.Float64()))
+//	})
+func MapValue(key string, fn func(slog.Value) slog.Value) Transformer {
+	return func(e *Event) bool {
+		if fn == nil {
+			return true
+		}
+		for i := range e.Attrs {
+			if e.Attrs[i].Key == key {
+				e.Attrs[i].Value = fn(e.Attrs[i].Value)
+			}
+		}
+		return true
+	}
+}
+
+// transform runs the configured transformers over an entry, reporting false
+// when one of them dropped it.
+func (l *Logger) transform(ctx context.Context, t time.Time, pc uintptr, level slog.Level, msg string, attrs []slog.Attr) (Event, bool) {
+	e := Event{Context: ctx, Time: t, PC: pc, Level: level, Message: msg, Attrs: attrs}
+	for _, tr := range l.transformers {
+		if !tr(&e) {
+			return e, false
+		}
+	}
+	return e, true
+}
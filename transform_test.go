@@ -0,0 +1,80 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+)
+
+// TestTransformersBuiltins verifies the built-in transformers run in order.
+func TestTransformersBuiltins(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithTransformers(
+		RenameKey("peer.address", "client"),
+		nil,
+		DropKeys("grpc.start_time"),
+		AddStatic(slog.String("team", "payments")),
+		MapValue("grpc.code", func(v slog.Value) slog.Value { return slog.StringValue("code:" + v.String()) }),
+	))
+
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "finished call",
+		"peer.address", "10.0.0.1:1",
+		"grpc.start_time", "2026-01-01T00:00:00Z",
+		"grpc.code", "OK",
+	)
+	got := collectAttrs(rec.records[0])
+	want := map[string]any{"client": "10.0.0.1:1", "team": "payments", "grpc.code": "code:OK"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+// TestTransformersRewriteAndDrop verifies transformers can change the level
+// and message and drop entries.
+func TestTransformersRewriteAndDrop(t *testing.T) {
+	rec := &recordingHandler{}
+	var calls int
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithTransformers(
+		func(e *Event) bool {
+			if e.Message == "request received" {
+				return false
+			}
+			e.Level = slog.LevelWarn
+			e.Message = "rpc " + e.Message
+			return true
+		},
+		func(*Event) bool {
+			calls++
+			return true
+		},
+	))
+
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "request received")
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "finished call")
+	if len(rec.records) != 1 {
+		t.Fatalf("expected the dropped entry to be skipped, got %d records", len(rec.records))
+	}
+	if r := rec.records[0]; r.Level != slog.LevelWarn || r.Message != "rpc finished call" {
+		t.Fatalf("expected rewritten level and message, got %v %q", r.Level, r.Message)
+	}
+	if calls != 1 {
+		t.Fatalf("expected later transformers to be skipped after a drop, ran %d times", calls)
+	}
+}
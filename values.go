@@ -0,0 +1,200 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxErrorChain caps the wrapped errors listed for one error value.
+const maxErrorChain = 16
+
+// DurationFormat selects how [time.Duration] field values are logged.
+type DurationFormat int
+
+const (
+	// DurationSeconds logs durations as decimal seconds with an "s" suffix,
+	// such as "1.5s", the format Cloud Logging uses for durations.
+	DurationSeconds DurationFormat = iota
+	// DurationMillis logs durations as fractional milliseconds.
+	DurationMillis
+)
+
+// WithDurationFormat makes [Logger.Log] render [time.Duration] field values in
+// format. The default is [DurationSeconds].
+func WithDurationFormat(format DurationFormat) LoggerOption {
+	return func(cfg *loggerConfig) {
+		cfg.durationFormat = format
+	}
+}
+
+// typedValue converts a go-grpc-middleware field value to a slog.Value.
+// Durations use format, RFC 3339 timestamp strings such as grpc.start_time
+// become time values, errors become groups describing their chain, and
+// [slog.LogValuer]s are kept unresolved until the entry is written.
+func typedValue(v any, format DurationFormat) slog.Value {
+	switch x := v.(type) {
+	case time.Duration:
+		return durationValue(x, format)
+	case string:
+		if t, ok := parseTimestamp(x); ok {
+			return slog.TimeValue(t)
+		}
+		return slog.StringValue(x)
+	case slog.LogValuer:
+		return slog.AnyValue(x)
+	case error:
+		return errorValue(x)
+	default:
+		return slog.AnyValue(v)
+	}
+}
+
+// middlewareDurations lists the go-grpc-middleware fields that carry
+// durations as [time.Duration.String] text.
+var middlewareDurations = map[string]bool{
+	"grpc.duration":      true,
+	"grpc.send.duration": true,
+	"grpc.recv.duration": true,
+}
+
+// fieldValue converts the value of the field key. It parses the duration
+// strings go-grpc-middleware writes for its own fields: grpc.duration,
+// grpc.send.duration and grpc.recv.duration are rendered in format, and grpc.time_ms, whose key names
+// its unit, becomes a number of milliseconds.
+func fieldValue(key string, v any, format DurationFormat) slog.Value {
+	if s, ok := v.(string); ok {
+		switch {
+		case key == "grpc.time_ms":
+			if ms, err := strconv.ParseFloat(s, 64); err == nil {
+				return slog.Float64Value(ms)
+			}
+		case middlewareDurations[key]:
+			if d, err := time.ParseDuration(s); err == nil {
+				return durationValue(d, format)
+			}
+		}
+	}
+	return typedValue(v, format)
+}
+
+// durationValue renders d in format.
+func durationValue(d time.Duration, format DurationFormat) slog.Value {
+	if format == DurationMillis {
+		return slog.Float64Value(durationMillis(d))
+	}
+	return slog.StringValue(formatSeconds(d))
+}
+
+// formatSeconds formats d as decimal seconds without rounding, such as "1.5s".
+func formatSeconds(d time.Duration) string {
+	sign := ""
+	u := uint64(d)
+	if d < 0 {
+		sign, u = "-", uint64(-d)
+	}
+	secs := strconv.FormatUint(u/uint64(time.Second), 10)
+	frac := u % uint64(time.Second)
+	if frac == 0 {
+		return sign + secs + "s"
+	}
+	return sign + secs + "." + strings.TrimRight(strconv.FormatUint(frac+uint64(time.Second), 10)[1:], "0") + "s"
+}
+
+// parseTimestamp parses s if it looks like an RFC 3339 timestamp.
+func parseTimestamp(s string) (time.Time, bool) {
+	if len(s) < len("2006-01-02T15:04:05Z") || s[4] != '-' || s[7] != '-' || (s[10] != 'T' && s[10] != 't') {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	return t, err == nil
+}
+
+// errorValue renders err as a group with its message, its type and the
+// errors it wraps. The message keeps the error itself so handlers such as
+// slogcp still report it to Error Reporting.
+func errorValue(err error) slog.Value {
+	attrs := []slog.Attr{
+		slog.Any("message", err),
+		slog.String("type", fmt.Sprintf("%T", err)),
+	}
+	if chain := errorChain(err); len(chain) > 0 {
+		attrs = append(attrs, slog.Any("chain", chain))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// errorChain lists the errors wrapped by err, depth first.
+func errorChain(err error) []map[string]string {
+	var chain []map[string]string
+	pending := unwrapErrors(err)
+	for len(pending) > 0 && len(chain) < maxErrorChain {
+		next := pending[0]
+		pending = append(unwrapErrors(next), pending[1:]...)
+		chain = append(chain, map[string]string{"type": fmt.Sprintf("%T", next), "message": next.Error()})
+	}
+	return chain
+}
+
+// unwrapErrors returns the non-nil errors err wraps directly.
+func unwrapErrors(err error) []error {
+	var wrapped []error
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		wrapped = []error{x.Unwrap()}
+	case interface{ Unwrap() []error }:
+		wrapped = x.Unwrap()
+	}
+	out := wrapped[:0:0]
+	for _, e := range wrapped {
+		if e != nil {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// setError records the error a handler or invoker returned for call.
+func (call *callState) setError(err error) {
+	if err != nil {
+		call.err.Store(&err)
+	}
+}
+
+// callErrorAttrs replaces the grpc.error string go-grpc-middleware formats
+// with the error call recorded, rendered by errorValue, so finish entries
+// carry its type and chain. The replacement is redacted like the fields it
+// joins, since the chain can hold messages the string did not. A string that
+// no longer matches the error, for example because it was redacted, is kept.
+func (l *Logger) callErrorAttrs(call *callState, attrs []slog.Attr) []slog.Attr {
+	p := call.err.Load()
+	if p == nil {
+		return attrs
+	}
+	err := *p
+	for i, a := range attrs {
+		if a.Key != "grpc.error" || a.Value.Kind() != slog.KindString || a.Value.String() != fmt.Sprintf("%v", err) {
+			continue
+		}
+		if rendered := l.redact([]slog.Attr{{Key: a.Key, Value: errorValue(err)}}); len(rendered) == 1 {
+			attrs[i] = rendered[0]
+		}
+	}
+	return attrs
+}
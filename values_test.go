@@ -0,0 +1,215 @@
+// Copyright 2025-2026 Patrick J. Scruggs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slogcpadapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+)
+
+// countingValuer counts how often it is resolved.
+type countingValuer struct{ resolved *int }
+
+// LogValue returns a fixed value.
+func (v countingValuer) LogValue() slog.Value {
+	*v.resolved++
+	return slog.StringValue("resolved")
+}
+
+// TestTypedValueDurations verifies both duration formats.
+func TestTypedValueDurations(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{1500 * time.Millisecond, "1.5s"},
+		{90 * time.Second, "90s"},
+		{time.Microsecond, "0.000001s"},
+		{0, "0s"},
+		{-250 * time.Millisecond, "-0.25s"},
+	}
+	for _, tt := range tests {
+		if got := typedValue(tt.d, DurationSeconds); got.Kind() != slog.KindString || got.String() != tt.want {
+			t.Errorf("typedValue(%v) = %v, want %q", tt.d, got, tt.want)
+		}
+	}
+	if got := typedValue(1500*time.Microsecond, DurationMillis); got.Kind() != slog.KindFloat64 || got.Float64() != 1.5 {
+		t.Errorf("expected 1.5 milliseconds, got %v", got)
+	}
+}
+
+// TestTypedValueTimestamps verifies RFC 3339 strings become time values and
+// other strings are kept.
+func TestTypedValueTimestamps(t *testing.T) {
+	got := typedValue("2026-03-04T05:06:07.123Z", DurationSeconds)
+	want := time.Date(2026, 3, 4, 5, 6, 7, 123e6, time.UTC)
+	if got.Kind() != slog.KindTime || !got.Time().Equal(want) {
+		t.Fatalf("expected time value %v, got %v", want, got)
+	}
+	for _, s := range []string{"2026-03-04", "2026-03-04T25:00:00Z", "order 2026-03-04T05:06:07Z", "hello"} {
+		if v := typedValue(s, DurationSeconds); v.Kind() != slog.KindString || v.String() != s {
+			t.Errorf("expected %q to stay a string, got %v", s, v)
+		}
+	}
+}
+
+// TestTypedValueErrors verifies errors are logged with their type and chain.
+func TestTypedValueErrors(t *testing.T) {
+	pathErr := &fs.PathError{Op: "open", Path: "/etc/app.yaml", Err: fs.ErrNotExist}
+	err := fmt.Errorf("loading config: %w", errors.Join(pathErr, context.Canceled))
+
+	v := typedValue(err, DurationSeconds)
+	if v.Kind() != slog.KindGroup {
+		t.Fatalf("expected a group, got %v", v.Kind())
+	}
+	if msg := attrFromGroup(t, v, "message"); msg.Any() != err {
+		t.Fatalf("expected the message to keep the error for Error Reporting, got %v", msg)
+	}
+	if typ := attrFromGroup(t, v, "type").String(); typ != "*fmt.wrapError" {
+		t.Fatalf("unexpected type %q", typ)
+	}
+	wantChain := []map[string]string{
+		{"type": "*errors.joinError", "message": errors.Join(pathErr, context.Canceled).Error()},
+		{"type": "*fs.PathError", "message": pathErr.Error()},
+		{"type": "*errors.errorString", "message": fs.ErrNotExist.Error()},
+		{"type": "*errors.errorString", "message": context.Canceled.Error()},
+	}
+	if chain := attrFromGroup(t, v, "chain").Any(); !reflect.DeepEqual(chain, wantChain) {
+		t.Fatalf("expected chain %v, got %v", wantChain, chain)
+	}
+	if _, ok := findGroupAttr(typedValue(errors.New("plain"), DurationSeconds), "chain"); ok {
+		t.Fatal("expected no chain for an unwrapped error")
+	}
+}
+
+// TestLoggerResolvesLogValuersLazily verifies LogValuers are resolved once, when written.
+func TestLoggerResolvesLogValuersLazily(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithDurationFormat(DurationMillis))
+	var resolved int
+
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "finished call",
+		"lazy", countingValuer{resolved: &resolved},
+		"grpc.elapsed", 2*time.Millisecond,
+	)
+	if resolved != 0 {
+		t.Fatalf("expected the valuer to be left for the handler, resolved %d times", resolved)
+	}
+	lazy := attrValue(t, rec.records[0], "lazy")
+	if lazy.Kind() != slog.KindLogValuer || lazy.Resolve().String() != "resolved" {
+		t.Fatalf("expected an unresolved LogValuer, got %v", lazy.Kind())
+	}
+	if got := collectAttrs(rec.records[0])["grpc.elapsed"]; got != 2.0 {
+		t.Fatalf("expected 2 milliseconds, got %v", got)
+	}
+}
+
+// attrFromGroup returns the value of key in group v, failing if absent.
+func attrFromGroup(t *testing.T, v slog.Value, key string) slog.Value {
+	t.Helper()
+	a, ok := findGroupAttr(v, key)
+	if !ok {
+		t.Fatalf("group has no %q attribute: %v", key, v)
+	}
+	return a
+}
+
+// findGroupAttr looks up key in group v.
+func findGroupAttr(v slog.Value, key string) (slog.Value, bool) {
+	for _, a := range v.Group() {
+		if a.Key == key {
+			return a.Value, true
+		}
+	}
+	return slog.Value{}, false
+}
+
+// TestInterceptorRendersMiddlewareFields verifies the handler's error and the
+// middleware's duration strings are rendered as typed values.
+func TestInterceptorRendersMiddlewareFields(t *testing.T) {
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithDurationFormat(DurationMillis))
+	interceptor := logger.UnaryServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.FinishCall))
+	handlerErr := fmt.Errorf("loading config: %w", fs.ErrNotExist)
+
+	_, _ = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Get"}, func(context.Context, any) (any, error) {
+		return nil, handlerErr
+	})
+
+	r := rec.records[len(rec.records)-1]
+	grpcErr := attrValue(t, r, "grpc.error")
+	if grpcErr.Kind() != slog.KindGroup || attrFromGroup(t, grpcErr, "message").Any() != handlerErr {
+		t.Fatalf("expected grpc.error to carry the handler's error, got %v", grpcErr)
+	}
+	if typ := attrFromGroup(t, grpcErr, "type").String(); typ != "*fmt.wrapError" {
+		t.Fatalf("unexpected error type %q", typ)
+	}
+	if v := attrValue(t, r, "grpc.time_ms"); v.Kind() != slog.KindFloat64 {
+		t.Fatalf("expected grpc.time_ms as a number, got %v", v.Kind())
+	}
+
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "request received", "grpc.recv.duration", "1.5ms")
+	if got := collectAttrs(rec.records[len(rec.records)-1])["grpc.recv.duration"]; got != 1.5 {
+		t.Fatalf("expected 1.5 milliseconds, got %v", got)
+	}
+	logger.Log(context.Background(), grpc_logging.LevelInfo, "finished call", "grpc.duration", "2.5ms")
+	if got := collectAttrs(rec.records[len(rec.records)-1])["grpc.duration"]; got != 2.5 {
+		t.Fatalf("expected 2.5 milliseconds, got %v", got)
+	}
+}
+
+// opaqueError hides the error it wraps from its message.
+type opaqueError struct {
+	msg string
+	err error
+}
+
+func (e *opaqueError) Error() string { return e.msg }
+func (e *opaqueError) Unwrap() error { return e.err }
+
+// TestInterceptorRedactsHandlerError verifies the rendered handler error,
+// including the chain of errors it wraps, goes through the redactor.
+func TestInterceptorRedactsHandlerError(t *testing.T) {
+	redactor, err := NewRedactor(nil, RedactionRule{Name: "email", ValuePattern: EmailPattern})
+	if err != nil {
+		t.Fatalf("NewRedactor: %v", err)
+	}
+	rec := &recordingHandler{}
+	logger := NewLogger(nil, WithLogger(slog.New(rec)), WithRedactor(redactor))
+	interceptor := logger.UnaryServerInterceptor(grpc_logging.WithLogOnEvents(grpc_logging.FinishCall))
+	handlerErr := &opaqueError{msg: "auth failed", err: errors.New("unknown user bob@example.com")}
+
+	_, _ = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/pkg.Svc/Get"}, func(context.Context, any) (any, error) {
+		return nil, handlerErr
+	})
+
+	grpcErr := attrValue(t, rec.records[len(rec.records)-1], "grpc.error")
+	if msg := attrFromGroup(t, grpcErr, "message").Any(); msg != handlerErr {
+		t.Fatalf("expected the unchanged message to keep the error, got %v", msg)
+	}
+	wantChain := []map[string]string{{"type": "*errors.errorString", "message": "unknown user " + RedactedValue}}
+	if chain := attrFromGroup(t, grpcErr, "chain").Any(); !reflect.DeepEqual(chain, wantChain) {
+		t.Fatalf("expected chain %v, got %v", wantChain, chain)
+	}
+}